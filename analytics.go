@@ -0,0 +1,244 @@
+package mediawiki
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const analyticsURL = "https://wikimedia.org/api/rest_v1/metrics"
+
+// Access methods for pageviews.
+const (
+	AccessAll       = "all-access"
+	AccessDesktop   = "desktop"
+	AccessMobileApp = "mobile-app"
+	AccessMobileWeb = "mobile-web"
+)
+
+// Access sites for unique devices.
+const (
+	AccessSiteAll     = "all-sites"
+	AccessSiteDesktop = "desktop-site"
+	AccessSiteMobile  = "mobile-site"
+)
+
+// Agent types for pageviews.
+const (
+	AgentAll       = "all-agents"
+	AgentUser      = "user"
+	AgentSpider    = "spider"
+	AgentAutomated = "automated"
+)
+
+// Granularity of analytics data.
+const (
+	GranularityHourly  = "hourly"
+	GranularityDaily   = "daily"
+	GranularityMonthly = "monthly"
+)
+
+// Editor types for editors and edits counts.
+const (
+	EditorTypeAll       = "all-editor-types"
+	EditorTypeAnonymous = "anonymous"
+	EditorTypeGroupBot  = "group-bot"
+	EditorTypeNameBot   = "name-bot"
+	EditorTypeUser      = "user"
+)
+
+// Page types for editors and edits counts.
+const (
+	PageTypeAll        = "all-page-types"
+	PageTypeContent    = "content"
+	PageTypeNonContent = "non-content"
+)
+
+// Activity levels for editors count.
+const (
+	ActivityLevelAll = "all-activity-levels"
+	ActivityLevel1   = "1..4-edits"
+	ActivityLevel5   = "5..24-edits"
+	ActivityLevel25  = "25..99-edits"
+	ActivityLevel100 = "100..-edits"
+)
+
+// Date formats used by analytics API.
+const (
+	analyticsHourFormat = "2006010215"
+	analyticsDayFormat  = "20060102"
+)
+
+// AnalyticsOptions optional parameters for analytics methods, empty values mean "all" and daily granularity.
+// Project defaults to the hostname of the client ("en.wikipedia.org"), "all-projects" is supported by aggregate methods.
+// Access is used for pageviews ("desktop") and unique devices ("desktop-site").
+type AnalyticsOptions struct {
+	Project       string
+	Access        string
+	Agent         string
+	Granularity   string
+	EditorType    string
+	PageType      string
+	ActivityLevel string
+}
+
+func (opts *AnalyticsOptions) defaults(project string, access string) {
+	if len(opts.Project) == 0 {
+		opts.Project = project
+	}
+
+	if len(opts.Access) == 0 {
+		opts.Access = access
+	}
+
+	if len(opts.Agent) == 0 {
+		opts.Agent = AgentAll
+	}
+
+	if len(opts.Granularity) == 0 {
+		opts.Granularity = GranularityDaily
+	}
+
+	if len(opts.EditorType) == 0 {
+		opts.EditorType = EditorTypeAll
+	}
+
+	if len(opts.PageType) == 0 {
+		opts.PageType = PageTypeAll
+	}
+
+	if len(opts.ActivityLevel) == 0 {
+		opts.ActivityLevel = ActivityLevelAll
+	}
+}
+
+// Pageviews number of views of the article or the whole project in the period starting at the Timestamp.
+type Pageviews struct {
+	Project     string    `json:"project"`
+	Article     string    `json:"article"`
+	Granularity string    `json:"granularity"`
+	Timestamp   time.Time `json:"-"`
+	Access      string    `json:"access"`
+	Agent       string    `json:"agent"`
+	Views       int       `json:"views"`
+}
+
+// UnmarshalJSON decode pageviews parsing the timestamp.
+func (pvs *Pageviews) UnmarshalJSON(data []byte) error {
+	type pageviews Pageviews
+	raw := struct {
+		*pageviews
+		Timestamp string `json:"timestamp"`
+	}{
+		pageviews: (*pageviews)(pvs),
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	pvs.Timestamp = parseAnalyticsTime(raw.Timestamp)
+	return nil
+}
+
+// TopArticle article in the list of most viewed.
+type TopArticle struct {
+	Article string `json:"article"`
+	Views   int    `json:"views"`
+	Rank    int    `json:"rank"`
+}
+
+// UniqueDevices estimated number of unique devices in the period starting at the Timestamp.
+type UniqueDevices struct {
+	Project       string    `json:"project"`
+	AccessSite    string    `json:"access-site"`
+	Granularity   string    `json:"granularity"`
+	Timestamp     time.Time `json:"-"`
+	Devices       int       `json:"devices"`
+	Offset        int       `json:"offset"`
+	Underestimate int       `json:"underestimate"`
+}
+
+// UnmarshalJSON decode unique devices parsing the timestamp.
+func (uds *UniqueDevices) UnmarshalJSON(data []byte) error {
+	type uniqueDevices UniqueDevices
+	raw := struct {
+		*uniqueDevices
+		Timestamp string `json:"timestamp"`
+	}{
+		uniqueDevices: (*uniqueDevices)(uds),
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	uds.Timestamp = parseAnalyticsTime(raw.Timestamp)
+	return nil
+}
+
+// EditorsCount number of editors in the period starting at the Timestamp.
+type EditorsCount struct {
+	Timestamp time.Time `json:"timestamp"`
+	Editors   int       `json:"editors"`
+}
+
+// EditsCount number of edits in the period starting at the Timestamp.
+type EditsCount struct {
+	Timestamp time.Time `json:"timestamp"`
+	Edits     int       `json:"edits"`
+}
+
+type pageviewsResponse struct {
+	Items []Pageviews `json:"items"`
+}
+
+type topArticlesResponse struct {
+	Items []struct {
+		Articles []TopArticle `json:"articles"`
+	} `json:"items"`
+}
+
+type uniqueDevicesResponse struct {
+	Items []UniqueDevices `json:"items"`
+}
+
+type editorsResponse struct {
+	Items []struct {
+		Results []EditorsCount `json:"results"`
+	} `json:"items"`
+}
+
+type editsResponse struct {
+	Items []struct {
+		Results []EditsCount `json:"results"`
+	} `json:"items"`
+}
+
+// analyticsPath join path segments, segments have to be escaped by the caller.
+func analyticsPath(segments ...string) string {
+	return "/" + strings.Join(segments, "/")
+}
+
+// analyticsProject get project name from the client url.
+func analyticsProject(clientURL string) string {
+	if u, err := url.Parse(clientURL); err == nil && len(u.Hostname()) > 0 {
+		return u.Hostname()
+	}
+
+	return clientURL
+}
+
+// parseAnalyticsTime parse "2006010215" and "20060102" timestamps, other values are ignored.
+func parseAnalyticsTime(value string) time.Time {
+	for _, layout := range []string{analyticsHourFormat, analyticsDayFormat} {
+		if len(value) == len(layout) {
+			if ts, err := time.Parse(layout, value); err == nil {
+				return ts
+			}
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,146 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const analyticsTestURL = "/metrics"
+const analyticsTestProject = "en.wikipedia.org"
+
+func createAnalyticsServer() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := ""
+
+		switch r.URL.EscapedPath() {
+		case analyticsTestURL + "/pageviews/per-article/en.wikipedia.org/all-access/user/Albert_Einstein%3F/daily/2021010100/2021010200":
+			res = `{"items": [
+				{"project": "en.wikipedia", "article": "Albert_Einstein?", "granularity": "daily", "timestamp": "2021010100", "access": "all-access", "agent": "user", "views": 100},
+				{"project": "en.wikipedia", "article": "Albert_Einstein?", "granularity": "daily", "timestamp": "2021010200", "access": "all-access", "agent": "user", "views": 200}
+			]}`
+		case analyticsTestURL + "/pageviews/aggregate/en.wikipedia.org/desktop/all-agents/monthly/2021010100/2021013100":
+			res = `{"items": [{"project": "en.wikipedia", "access": "desktop", "agent": "all-agents", "granularity": "monthly", "timestamp": "2021010100", "views": 1000}]}`
+		case analyticsTestURL + "/pageviews/top/en.wikipedia.org/all-access/2021/01/05":
+			res = `{"items": [{"project": "en.wikipedia", "access": "all-access", "year": "2021", "month": "01", "day": "05", "articles": [
+				{"article": "Main_Page", "views": 5000, "rank": 1},
+				{"article": "Special:Search", "views": 3000, "rank": 2}
+			]}]}`
+		case analyticsTestURL + "/pageviews/top/en.wikipedia.org/all-access/2021/01/all-days":
+			res = `{"items": [{"articles": [{"article": "Main_Page", "views": 150000, "rank": 1}]}]}`
+		case analyticsTestURL + "/unique-devices/en.wikipedia.org/all-sites/daily/20210101/20210101":
+			res = `{"items": [{"project": "en.wikipedia", "access-site": "all-sites", "granularity": "daily", "timestamp": "20210101", "devices": 42, "offset": 2, "underestimate": 40}]}`
+		case analyticsTestURL + "/editors/aggregate/en.wikipedia.org/user/content/5..24-edits/monthly/20210101/20210301":
+			res = `{"items": [{"project": "en.wikipedia", "results": [
+				{"timestamp": "2021-01-01T00:00:00.000Z", "editors": 10},
+				{"timestamp": "2021-02-01T00:00:00.000Z", "editors": 12}
+			]}]}`
+		case analyticsTestURL + "/edits/aggregate/en.wikipedia.org/all-editor-types/all-page-types/daily/20210101/20210102":
+			res = `{"items": [{"project": "en.wikipedia", "results": [{"timestamp": "2021-01-01T00:00:00.000Z", "edits": 7}]}]}`
+		case analyticsTestURL + "/pageviews/per-article/en.wikipedia.org/all-access/all-agents/Bad/daily/2021010100/2021010200":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, err := w.Write([]byte(res))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+}
+
+func TestAnalytics(t *testing.T) {
+	srv := httptest.NewServer(createAnalyticsServer())
+	defer srv.Close()
+
+	client := NewClient("https://" + analyticsTestProject)
+	client.options.AnalyticsURL = srv.URL + analyticsTestURL
+	ctx := context.Background()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("pageviews per article", func(t *testing.T) {
+		views, err := client.PageviewsPerArticle(ctx, "Albert Einstein?", start, start.AddDate(0, 0, 1), AnalyticsOptions{
+			Project: analyticsTestProject,
+			Agent:   AgentUser,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, views, 2)
+		assert.Equal(t, 100, views[0].Views)
+		assert.Equal(t, start, views[0].Timestamp)
+		assert.Equal(t, start.AddDate(0, 0, 1), views[1].Timestamp)
+		assert.Equal(t, "Albert_Einstein?", views[1].Article)
+	})
+
+	t.Run("pageviews aggregate", func(t *testing.T) {
+		views, err := client.PageviewsAggregate(ctx, start, start.AddDate(0, 0, 30), AnalyticsOptions{
+			Access:      AccessDesktop,
+			Granularity: GranularityMonthly,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, views, 1)
+		assert.Equal(t, 1000, views[0].Views)
+		assert.Equal(t, AccessDesktop, views[0].Access)
+	})
+
+	t.Run("top articles", func(t *testing.T) {
+		articles, err := client.TopArticles(ctx, start.AddDate(0, 0, 4))
+		assert.NoError(t, err)
+		assert.Len(t, articles, 2)
+		assert.Equal(t, "Main_Page", articles[0].Article)
+		assert.Equal(t, 2, articles[1].Rank)
+
+		articles, err = client.TopArticles(ctx, start, AnalyticsOptions{Granularity: GranularityMonthly})
+		assert.NoError(t, err)
+		assert.Len(t, articles, 1)
+		assert.Equal(t, 150000, articles[0].Views)
+	})
+
+	t.Run("unique devices", func(t *testing.T) {
+		devices, err := client.UniqueDevices(ctx, start, start)
+		assert.NoError(t, err)
+		assert.Len(t, devices, 1)
+		assert.Equal(t, 42, devices[0].Devices)
+		assert.Equal(t, start, devices[0].Timestamp)
+	})
+
+	t.Run("editors", func(t *testing.T) {
+		editors, err := client.Editors(ctx, start, start.AddDate(0, 2, 0), AnalyticsOptions{
+			EditorType:    EditorTypeUser,
+			PageType:      PageTypeContent,
+			ActivityLevel: ActivityLevel5,
+			Granularity:   GranularityMonthly,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, editors, 2)
+		assert.Equal(t, 12, editors[1].Editors)
+		assert.Equal(t, start.AddDate(0, 1, 0), editors[1].Timestamp)
+	})
+
+	t.Run("edits", func(t *testing.T) {
+		edits, err := client.Edits(ctx, start, start.AddDate(0, 0, 1))
+		assert.NoError(t, err)
+		assert.Len(t, edits, 1)
+		assert.Equal(t, 7, edits[0].Edits)
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		_, err := client.PageviewsPerArticle(ctx, "Missing", start, start.AddDate(0, 0, 1))
+		assert.True(t, errors.Is(err, ErrEmptyResult))
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		_, err := client.PageviewsPerArticle(ctx, "Bad", start, start.AddDate(0, 0, 1))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrEmptyResult))
+	})
+}
@@ -41,6 +41,12 @@ func (cb *ClientBuilder) Headers(headers map[string]string) *ClientBuilder {
 	return cb
 }
 
+// SiteInfoTTL set how long site info is cached, zero disables the cache.
+func (cb *ClientBuilder) SiteInfoTTL(ttl time.Duration) *ClientBuilder {
+	cb.client.siteInfoTTL = ttl
+	return cb
+}
+
 // Build create new client instance
 func (cb *ClientBuilder) Build() *Client {
 	return cb.client
@@ -18,6 +18,15 @@ const builderTestNamespacesURL = "/namespaces"
 const builderTestPageRevisionsURL = "/revisions"
 const builderTestPagesDataURL = "/pages-data"
 const builderTestUserURL = "/users"
+const builderTestTitlesURL = "/titles"
+const builderTestSiteInfoURL = "/siteinfo"
+const builderTestWikibaseURL = "/wikibase"
+const builderTestTokenURL = "/token"
+const builderTestAnalyticsURL = "http://localhost:5001/metrics"
+const builderTestScoreURL = "http://localhost:5001/models"
+const builderTestTitleHistoryURL = "/title-history"
+const builderTestEntityDataURL = "/entity-data/%s.json"
+const builderTestSiteInfoTTL = time.Minute
 const builderTestHeaderName = "User-Agent"
 const builderTestHeaderValue = "test@test.com"
 
@@ -39,7 +48,16 @@ func TestBuilder(t *testing.T) {
 			builderTestNamespacesURL,
 			builderTestPagesDataURL,
 			builderTestUserURL,
+			builderTestTitlesURL,
+			builderTestSiteInfoURL,
+			builderTestWikibaseURL,
+			builderTestTokenURL,
+			builderTestAnalyticsURL,
+			builderTestScoreURL,
+			builderTestTitleHistoryURL,
+			builderTestEntityDataURL,
 		}).
+		SiteInfoTTL(builderTestSiteInfoTTL).
 		Headers(map[string]string{
 			builderTestHeaderName: builderTestHeaderValue,
 		})
@@ -56,6 +74,15 @@ func TestBuilder(t *testing.T) {
 	assert.Equal(t, builderTestNamespacesURL, client.options.NamespacesURL)
 	assert.Equal(t, builderTestPagesDataURL, client.options.PageDataURL)
 	assert.Equal(t, builderTestUserURL, client.options.UserURL)
+	assert.Equal(t, builderTestTitlesURL, client.options.TitlesURL)
+	assert.Equal(t, builderTestSiteInfoURL, client.options.SiteInfoURL)
+	assert.Equal(t, builderTestWikibaseURL, client.options.WikibaseURL)
+	assert.Equal(t, builderTestTokenURL, client.options.TokenURL)
+	assert.Equal(t, builderTestAnalyticsURL, client.options.AnalyticsURL)
+	assert.Equal(t, builderTestScoreURL, client.options.ScoreURL)
+	assert.Equal(t, builderTestTitleHistoryURL, client.options.TitleHistoryURL)
+	assert.Equal(t, builderTestEntityDataURL, client.options.EntityDataURL)
+	assert.Equal(t, builderTestSiteInfoTTL, client.siteInfoTTL)
 	assert.Equal(t, builderTestTimeout, client.httpClient.Timeout)
 	assert.Equal(t, builderTestHeaderValue, client.headers[builderTestHeaderName])
 }
@@ -2,14 +2,21 @@
 package mediawiki
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/protsack-stephan/mediawiki-api-client/sparql"
 )
 
 // ErrEmptyResult no items in result list.
@@ -23,6 +30,28 @@ var ErrUserNotFound = errors.New("user not found")
 
 const errBadRequestMsg = "status: '%d' body: '%s'"
 
+// APIError error returned by actions API in the response body.
+type APIError struct {
+	Code string `json:"code"`
+	Info string `json:"info"`
+}
+
+// Error get error message.
+func (err *APIError) Error() string {
+	return fmt.Sprintf("api error: '%s' info: '%s'", err.Code, err.Info)
+}
+
+// Is match api error against sentinel errors like ErrEditConflict.
+func (err *APIError) Is(target error) bool {
+	for _, code := range apiErrorCodes[target] {
+		if err.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
 // NewClient create new client instance
 func NewClient(url string) *Client {
 	return &Client{
@@ -37,22 +66,39 @@ func NewClient(url string) *Client {
 			namespacesURL,
 			pageDataURL,
 			userURL,
+			titlesURL,
+			siteInfoURL,
+			wikibaseURL,
+			tokenURL,
+			analyticsURL,
+			scoreURL,
+			titleHistoryURL,
+			entityDataURL,
 		},
+		siteInfoTTL: siteInfoTTL,
+		siteInfo:    new(siteInfoCache),
+		tokens:      new(tokenCache),
+		registryMu:  new(sync.Mutex),
 	}
 }
 
 // Client wikimedia api client.
 type Client struct {
-	url        string
-	httpClient *http.Client
-	headers    map[string]string
-	options    *Options
+	url         string
+	httpClient  *http.Client
+	headers     map[string]string
+	options     *Options
+	siteInfoTTL time.Duration
+	siteInfo    *siteInfoCache
+	tokens      *tokenCache
+	registryMu  *sync.Mutex
+	registry    *Registry
 }
 
 // PageMeta get page meta data.
 func (cl *Client) PageMeta(ctx context.Context, title string) (*PageMeta, error) {
 	meta := new(PageMeta)
-	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.PageMetaURL+url.QueryEscape(title), nil, cl.headers)
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.PageMetaURL+escapeTitle(title), nil, cl.headers)
 
 	if err != nil {
 		return meta, err
@@ -77,7 +123,190 @@ func (cl *Client) PageMeta(ctx context.Context, title string) (*PageMeta, error)
 }
 
 // PagesData get page data from Actions API.
+// Result is keyed by the requested titles, redirects are followed unless ReportRedirects option is set.
+// Interwiki redirects can't be followed, they are left out and reported as interwiki by PagesDataDetailed.
 func (cl *Client) PagesData(ctx context.Context, titles []string, options ...PageDataOptions) (map[string]PageData, error) {
+	res, err := cl.PagesDataDetailed(ctx, titles, options...)
+
+	if err != nil {
+		return make(map[string]PageData), err
+	}
+
+	return res.Pages, nil
+}
+
+// PagesDataDetailed get page data from Actions API, same as PagesData, but titles that don't resolve
+// to existing pages are reported as missing, invalid, special or interwiki.
+// List props (links, langlinks, contributors, etc.) are continued until complete, their limit is shared by all titles.
+func (cl *Client) PagesDataDetailed(ctx context.Context, titles []string, options ...PageDataOptions) (*PagesDataResult, error) {
+	result := &PagesDataResult{
+		Pages:     map[string]PageData{},
+		Missing:   map[string]PageData{},
+		Invalid:   map[string]string{},
+		Special:   map[string]PageData{},
+		Interwiki: map[string]PagesDataInterwiki{},
+	}
+	report := false
+
+	for _, opt := range options {
+		report = opt.ReportRedirects
+	}
+
+	params := url.Values{
+		"titles": []string{strings.Join(titles, "|")},
+	}
+
+	if !report {
+		params.Set("redirects", "1")
+	}
+
+	res, err := cl.pagesDataContinued(ctx, params, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]PageData{}
+	interwiki := map[string]PagesDataInterwiki{}
+
+	for _, iw := range res.Query.Interwiki {
+		interwiki[iw.Title] = iw
+	}
+	redirects := []string{}
+
+	for _, page := range res.Query.Pages {
+		found[page.Title] = page
+
+		if page.Redirect {
+			redirects = append(redirects, page.Title)
+		}
+	}
+
+	// redirect pages are returned as is, chains are resolved by the separate lightweight query
+	resolved := res
+
+	if report && len(redirects) > 0 {
+		resolved, err = cl.pagesData(ctx, url.Values{
+			"titles":    []string{strings.Join(redirects, "|")},
+			"redirects": []string{"1"},
+		}, []PageDataOptions{{Props: []string{PageDataPropInfo}}})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, title := range titles {
+		rsv := res.resolve(title)
+		page, ok := found[rsv.target]
+
+		if report {
+			rsv = resolved.resolve(rsv.normalized)
+		}
+
+		// reported redirect to another wiki is a page of its own, followed one has no page here
+		if !report && len(rsv.interwiki) > 0 {
+			result.Interwiki[title] = PagesDataInterwiki{
+				Title:         rsv.interwiki + ":" + rsv.target,
+				Prefix:        rsv.interwiki,
+				Fragment:      rsv.fragment,
+				RedirectChain: rsv.chain,
+			}
+
+			continue
+		}
+
+		if !ok {
+			if iw, ok := interwiki[rsv.normalized]; ok {
+				result.Interwiki[title] = iw
+			} else if iw, ok := interwiki[title]; ok {
+				result.Interwiki[title] = iw
+			}
+
+			continue
+		}
+
+		rsv.apply(&page)
+
+		switch {
+		case page.Invalid:
+			result.Invalid[title] = page.InvalidReason
+		case page.Special:
+			result.Special[title] = page
+		case page.Missing:
+			result.Missing[title] = page
+		default:
+			result.Pages[title] = page
+		}
+	}
+
+	return result, nil
+}
+
+// PagesDataByIDs get page data by page ids, redirects are not followed.
+// Result is keyed by page id, ids of missing pages and invalid ids are returned separately.
+func (cl *Client) PagesDataByIDs(ctx context.Context, pageids []int, options ...PageDataOptions) (map[int]PageData, []int, error) {
+	pages := map[int]PageData{}
+	res, err := cl.pagesDataContinued(ctx, url.Values{
+		"pageids": []string{joinIDs(pageids)},
+	}, options)
+
+	if err != nil {
+		return pages, nil, err
+	}
+
+	for _, page := range res.Query.Pages {
+		if !page.Missing && !page.Invalid {
+			pages[page.PageID] = page
+		}
+	}
+
+	return pages, missingIDs(pageids, pages), nil
+}
+
+// PagesDataByRevIDs get page data by revision ids, Revisions of every page hold exactly the requested revision.
+// Result is keyed by revision id, ids of missing, deleted and invalid revisions are returned separately.
+func (cl *Client) PagesDataByRevIDs(ctx context.Context, revids []int, options ...PageDataOptions) (map[int]PageData, []int, error) {
+	pages := map[int]PageData{}
+	res, err := cl.pagesDataContinued(ctx, url.Values{
+		"revids": []string{joinIDs(revids)},
+	}, options)
+
+	if err != nil {
+		return pages, nil, err
+	}
+
+	for _, page := range res.Query.Pages {
+		if page.Missing || page.Invalid {
+			continue
+		}
+
+		for _, rev := range page.Revisions {
+			revPage := page
+			revPage.Revisions = []PageDataRevision{rev}
+			pages[rev.RevID] = revPage
+		}
+	}
+
+	return pages, missingIDs(revids, pages), nil
+}
+
+// PagesDataFromGenerator get page data for the pages listed by the generator, batch by batch.
+// Both generator and prop continuation are handled by the iterator.
+func (cl *Client) PagesDataFromGenerator(ctx context.Context, spec GeneratorSpec, options ...PageDataOptions) *PageDataIterator {
+	params, err := spec.params()
+
+	return &PageDataIterator{
+		ctx:     ctx,
+		client:  cl,
+		params:  params,
+		options: options,
+		err:     err,
+	}
+}
+
+// pagesData query page data for titles, pageids or revids passed in params.
+func (cl *Client) pagesData(ctx context.Context, params url.Values, options []PageDataOptions) (*pageDataResponse, error) {
 	var rvProps []string
 	rvLimit := 1
 	clLimit := 500
@@ -85,8 +314,10 @@ func (cl *Client) PagesData(ctx context.Context, titles []string, options ...Pag
 	rdLimit := 500
 	wbeuLimit := 500
 	clProps := []string{"hidden"}
+	slots := []string{SlotMain}
+	props := PageDataDefaultProps
+	rvBaseProps := PageDataDefaultRevisionProps
 
-	pages := make(map[string]PageData)
 	res := new(pageDataResponse)
 
 	for _, opt := range options {
@@ -112,32 +343,55 @@ func (cl *Client) PagesData(ctx context.Context, titles []string, options ...Pag
 		if len(opt.CategoriesProps) > 0 {
 			clProps = opt.CategoriesProps
 		}
+
+		if len(opt.Slots) > 0 {
+			slots = opt.Slots
+		}
+
+		if len(opt.Props) > 0 {
+			props = opt.Props
+		}
+
+		if len(opt.BaseRevisionProps) > 0 {
+			rvBaseProps = opt.BaseRevisionProps
+		}
 	}
 
 	body := url.Values{
 		"action":        []string{"query"},
-		"prop":          []string{"info|categories|revisions|templates|wbentityusage|pageprops|redirects|flagged"},
-		"rvprop":        []string{"comment|oresscores|content|ids|timestamp|tags|user|userid|flags"},
-		"rvslots":       []string{"main"},
-		"inprop":        []string{"displaytitle|protection|url|watchers"},
-		"ppprop":        []string{"wikibase_item"},
-		"redirects":     []string{"1"},
-		"titles":        []string{strings.Join(titles, "|")},
+		"prop":          []string{strings.Join(props, "|")},
 		"formatversion": []string{"2"},
 		"format":        []string{"json"},
-		"clprop":        []string{strings.Join(clProps, "|")},
-		"cllimit":       []string{fmt.Sprintf("%d", clLimit)},
-		"tllimit":       []string{fmt.Sprintf("%d", tlLimit)},
-		"rdlimit":       []string{fmt.Sprintf("%d", rdLimit)},
-		"wbeulimit":     []string{fmt.Sprintf("%d", wbeuLimit)},
 	}
 
-	if rvLimit > 1 {
-		body["rvlimit"] = []string{fmt.Sprintf("%d", rvLimit)}
+	for _, prop := range props {
+		for key, value := range pageDataPropParams[prop] {
+			body.Set(key, value)
+		}
+
+		switch prop {
+		case PageDataPropRevisions:
+			body.Set("rvprop", strings.Join(append(append([]string{}, rvBaseProps...), rvProps...), "|"))
+			body.Set("rvslots", strings.Join(slots, "|"))
+
+			// revisions limit can't be used with revids, the requested revisions are returned
+			if _, ok := params["revids"]; !ok && rvLimit > 1 {
+				body.Set("rvlimit", fmt.Sprintf("%d", rvLimit))
+			}
+		case PageDataPropCategories:
+			body.Set("clprop", strings.Join(clProps, "|"))
+			body.Set("cllimit", fmt.Sprintf("%d", clLimit))
+		case PageDataPropTemplates:
+			body.Set("tllimit", fmt.Sprintf("%d", tlLimit))
+		case PageDataPropRedirects:
+			body.Set("rdlimit", fmt.Sprintf("%d", rdLimit))
+		case PageDataPropWbEntityUsage:
+			body.Set("wbeulimit", fmt.Sprintf("%d", wbeuLimit))
+		}
 	}
 
-	if len(rvProps) > 0 {
-		body["rvprop"][0] += fmt.Sprintf("|%s", strings.Join(rvProps, "|"))
+	for key, value := range params {
+		body[key] = value
 	}
 
 	data, status, err := req(
@@ -151,42 +405,86 @@ func (cl *Client) PagesData(ctx context.Context, titles []string, options ...Pag
 		}, cl.headers)
 
 	if err != nil {
-		return pages, err
+		return nil, err
 	}
 
 	if status != http.StatusOK {
-		return pages, fmt.Errorf(errBadRequestMsg, status, data)
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
 	}
 
 	if err := json.Unmarshal(data, res); err != nil {
-		return pages, err
+		return nil, err
 	}
 
-	lookup := map[string]bool{}
+	return res, nil
+}
 
-	for _, title := range titles {
-		lookup[title] = true
+// pagesDataContinued query page data following prop continuation, values of the list props are merged into the pages.
+// Revisions requested with the limit are not continued past it, so the result matches a single request.
+func (cl *Client) pagesDataContinued(ctx context.Context, params url.Values, options []PageDataOptions) (*pageDataResponse, error) {
+	rvLimit := 1
+
+	for _, opt := range options {
+		rvLimit = opt.RevisionsLimit
 	}
 
-	normalized := map[string]string{}
+	_, byRevIDs := params["revids"]
+	limitRevisions := !byRevIDs && rvLimit > 1
+	lookup := map[string]int{}
+	cont := map[string]string{}
+	var res *pageDataResponse
+
+	for {
+		body := url.Values{}
+
+		for key, value := range params {
+			body[key] = value
+		}
+
+		for key, value := range cont {
+			body.Set(key, value)
+		}
+
+		next, err := cl.pagesData(ctx, body, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res == nil {
+			res = next
+
+			for idx, page := range res.Query.Pages {
+				lookup[page.Title] = idx
+			}
+		} else {
+			for _, page := range next.Query.Pages {
+				if idx, ok := lookup[page.Title]; ok {
+					mergePageData(&res.Query.Pages[idx], &page)
+				} else {
+					lookup[page.Title] = len(res.Query.Pages)
+					res.Query.Pages = append(res.Query.Pages, page)
+				}
+			}
+		}
+
+		cont = continueParams(next.Continue)
 
-	for _, title := range res.Query.Normalized {
-		if _, ok := lookup[title.From]; ok {
-			normalized[title.To] = title.From
+		if !hasContinuation(cont, limitRevisions) {
+			break
 		}
 	}
 
-	for _, page := range res.Query.Pages {
-		if !page.Missing {
-			if title, ok := normalized[page.Title]; ok {
-				pages[title] = page
-			} else if _, ok := lookup[page.Title]; ok {
-				pages[page.Title] = page
+	if limitRevisions {
+		for idx := range res.Query.Pages {
+			if len(res.Query.Pages[idx].Revisions) > rvLimit {
+				res.Query.Pages[idx].Revisions = res.Query.Pages[idx].Revisions[:rvLimit]
 			}
 		}
 	}
 
-	return pages, nil
+	res.Continue = nil
+	return res, nil
 }
 
 // PageData get page data from Actions API.
@@ -206,7 +504,7 @@ func (cl *Client) PageData(ctx context.Context, title string, options ...PageDat
 
 // PageHTML get page html with or without revision.
 func (cl *Client) PageHTML(ctx context.Context, title string, rev ...int) ([]byte, error) {
-	url := cl.url + cl.options.PageHTMLURL + url.QueryEscape(title)
+	url := cl.url + cl.options.PageHTMLURL + escapeTitle(title)
 
 	if len(rev) > 0 {
 		url += "/" + strconv.Itoa(rev[0])
@@ -225,47 +523,160 @@ func (cl *Client) PageHTML(ctx context.Context, title string, rev ...int) ([]byt
 	return data, nil
 }
 
+// PageHTMLWithMeta get page html together with ETag, language, profile version and revision.
+func (cl *Client) PageHTMLWithMeta(ctx context.Context, title string, options ...PageHTMLOptions) (*PageHTML, error) {
+	html := new(PageHTML)
+	opts := PageHTMLOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	reqURL := cl.url + cl.options.PageHTMLURL + escapeTitle(title)
+
+	if opts.Revision > 0 {
+		reqURL += "/" + strconv.Itoa(opts.Revision)
+	}
+
+	query := url.Values{}
+
+	if opts.NoRedirect {
+		query.Set("redirect", "false")
+	}
+
+	if opts.Stash {
+		query.Set("stash", "true")
+	}
+
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	headers := map[string]string{}
+
+	if len(opts.AcceptLanguage) > 0 {
+		headers["Accept-Language"] = opts.AcceptLanguage
+	}
+
+	data, status, header, err := reqWithHeaders(ctx, cl.httpClient, http.MethodGet, reqURL, nil, cl.headers, headers)
+
+	if err != nil {
+		return html, err
+	}
+
+	if status != http.StatusOK {
+		return html, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	html.Content = data
+	html.ETag = header.Get("ETag")
+	html.Revision, html.TID = parseETag(html.ETag)
+	html.ContentLanguage = header.Get("Content-Language")
+	html.ContentType = header.Get("Content-Type")
+	html.ProfileVersion = parseProfileVersion(html.ContentType)
+
+	if target, ok := parseRedirectTarget(data); ok {
+		html.Redirect = true
+
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+
+		html.RedirectTarget = strings.ReplaceAll(target, "_", " ")
+	}
+
+	return html, nil
+}
+
 // PageWikitext get page wikitext with or without revision.
 func (cl *Client) PageWikitext(ctx context.Context, title string, rev ...int) ([]byte, error) {
-	url := cl.url + fmt.Sprintf(cl.options.PageWikitextURL, url.QueryEscape(title))
+	return cl.PageWikitextSlot(ctx, title, SlotMain, rev...)
+}
+
+// PageWikitextSlot get content of the single revision slot (SlotMain, SlotMediaInfo, etc.) with or without revision.
+func (cl *Client) PageWikitextSlot(ctx context.Context, title string, role string, rev ...int) ([]byte, error) {
+	slots, err := cl.pageSlots(ctx, title, url.Values{
+		"rvslots": []string{role},
+	}, rev)
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	slot, ok := slots[role]
+
+	if !ok || slot.Missing {
+		return []byte{}, ErrEmptyResult
+	}
+
+	return []byte(slot.Content), nil
+}
+
+// PageSlots get content of the page revision slots with or without revision, roles default to SlotAll.
+func (cl *Client) PageSlots(ctx context.Context, title string, roles []string, rev ...int) (PageDataRevisionSlots, error) {
+	if len(roles) == 0 {
+		roles = []string{SlotAll}
+	}
+
+	return cl.pageSlots(ctx, title, url.Values{
+		"rvslots": []string{strings.Join(roles, "|")},
+		"rvprop":  []string{"content|size|sha1"},
+	}, rev)
+}
+
+// pageSlots query the revision slots, parameters replace the ones of the configured wikitext url.
+func (cl *Client) pageSlots(ctx context.Context, title string, params url.Values, rev []int) (PageDataRevisionSlots, error) {
+	reqURL, err := url.Parse(cl.url + fmt.Sprintf(cl.options.PageWikitextURL, url.QueryEscape(title)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := reqURL.Query()
+
+	for key, value := range params {
+		query[key] = value
+	}
 
 	if len(rev) > 0 {
-		url += "&rvstartid=" + strconv.Itoa(rev[0])
+		query.Set("rvstartid", strconv.Itoa(rev[0]))
 	}
 
-	data, status, err := req(ctx, cl.httpClient, http.MethodGet, url, nil, cl.headers)
+	reqURL.RawQuery = query.Encode()
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, reqURL.String(), nil, cl.headers)
 
 	if err != nil {
-		return []byte{}, err
+		return nil, err
 	}
 
 	if status != http.StatusOK {
-		return []byte{}, fmt.Errorf(errBadRequestMsg, status, data)
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
 	}
 
 	res := new(wikitextResponse)
-	err = json.Unmarshal(data, res)
 
-	if err != nil {
-		return []byte{}, err
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, err
 	}
 
 	if len(res.Query.Pages) <= 0 || len(res.Query.Pages[0].Revisions) <= 0 {
-		return []byte{}, ErrEmptyResult
+		return nil, ErrEmptyResult
 	}
 
-	return []byte(res.Query.Pages[0].Revisions[0].Slots.Main.Content), err
+	return res.Query.Pages[0].Revisions[0].Slots, nil
 }
 
 // PageRevisions get list of page revisions.
 func (cl *Client) PageRevisions(ctx context.Context, title string, limit int, options ...PageRevisionsOptions) ([]Revision, error) {
 	var props []string
+	var slots []string
 	revs := []Revision{}
 	ordering := RevisionOrderingOlder
 
 	for _, opt := range options {
 		ordering = opt.Order
 		props = opt.Props
+		slots = opt.Slots
 	}
 
 	body := url.Values{
@@ -282,19 +693,11 @@ func (cl *Client) PageRevisions(ctx context.Context, title string, limit int, op
 		body["rvprop"] = []string{strings.Join(props, "|")}
 	}
 
-	reqURL := cl.url + cl.options.PageRevisionsURL + body.Encode()
-	data, status, err := req(ctx, cl.httpClient, http.MethodGet, reqURL, nil, cl.headers)
-
-	if err != nil {
-		return revs, err
-	}
-
-	if status != http.StatusOK {
-		return revs, fmt.Errorf(errBadRequestMsg, status, data)
+	if len(slots) > 0 {
+		body["rvslots"] = []string{strings.Join(slots, "|")}
 	}
 
-	res := new(revisionsResponse)
-	err = json.Unmarshal(data, res)
+	res, err := cl.revisions(ctx, body)
 
 	if err != nil {
 		return revs, err
@@ -307,64 +710,1314 @@ func (cl *Client) PageRevisions(ctx context.Context, title string, limit int, op
 	return res.Query.Pages[0].Revisions, nil
 }
 
-// Sitematrix get all supported wikimedia projects.
-func (cl *Client) Sitematrix(ctx context.Context) (*Sitematrix, error) {
-	matrix := new(Sitematrix)
-	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.SitematrixURL, nil, cl.headers)
+// PageHistory get the whole history of the page, or the part of it limited by the options, following continuation.
+func (cl *Client) PageHistory(ctx context.Context, title string, options ...PageHistoryOptions) *RevisionIterator {
+	opts := PageHistoryOptions{}
 
-	if err != nil {
-		return matrix, err
+	for _, opt := range options {
+		opts = opt
 	}
 
-	if status != http.StatusOK {
-		return matrix, fmt.Errorf(errBadRequestMsg, status, data)
+	return &RevisionIterator{
+		ctx:    ctx,
+		client: cl,
+		params: opts.params(title),
 	}
+}
 
-	special := new(siteMatrixSpecialResponce)
-	err = json.Unmarshal(data, special)
+func (cl *Client) revisions(ctx context.Context, params url.Values) (*revisionsResponse, error) {
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.PageRevisionsURL+params.Encode(), nil, cl.headers)
 
 	if err != nil {
-		return matrix, err
+		return nil, err
 	}
 
-	matrix.Count, matrix.Specials = special.Sitematrix.Count, special.Sitematrix.Specials
-	main := new(siteMatrixMainResponse)
-	_ = json.Unmarshal(data, main)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
+	}
 
-	for num, project := range main.Sitematrix {
-		if num != "count" && num != "specials" {
-			matrix.Projects = append(matrix.Projects, project)
-		}
+	res := new(revisionsResponse)
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, err
 	}
 
-	return matrix, nil
+	return res, nil
 }
 
-// Namespaces get page types called "namespaces".
-func (cl *Client) Namespaces(ctx context.Context) ([]Namespace, error) {
-	ns := []Namespace{}
-	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.NamespacesURL, nil, cl.headers)
+// PageAt get the revision of the page that was current at the time, with it's wikitext and HTML.
+// When the title had no revisions at the time (the page was moved and the redirect was created later),
+// the current redirect is followed to the moved page.
+func (cl *Client) PageAt(ctx context.Context, title string, at time.Time) (*PageSnapshot, error) {
+	return cl.pageAt(ctx, []url.Values{
+		pageAtParams(title, at, false),
+		pageAtParams(title, at, true),
+	})
+}
 
-	if err != nil {
-		return ns, err
-	}
+// PagesAt get state of the pages at the same point in time, pages that didn't exist at the time are left out.
+// Titles and redirects are resolved in batches of 50, revisions are queried concurrently (one title per query is all the API allows).
+func (cl *Client) PagesAt(ctx context.Context, titles []string, at time.Time) (map[string]PageSnapshot, error) {
+	pages := map[string]PageSnapshot{}
+	queries := make([][]url.Values, len(titles))
 
-	if status != http.StatusOK {
-		return ns, fmt.Errorf(errBadRequestMsg, status, data)
-	}
+	for start := 0; start < len(titles); start += pageAtTitlesLimit {
+		end := start + pageAtTitlesLimit
 
-	res := new(namespacesResponse)
-	err = json.Unmarshal(data, res)
+		if end > len(titles) {
+			end = len(titles)
+		}
 
-	if err != nil {
-		return ns, err
-	}
+		res, err := cl.PagesDataDetailed(ctx, titles[start:end], PageDataOptions{
+			Props:           []string{PageDataPropInfo},
+			ReportRedirects: true,
+		})
 
-	for _, name := range res.Query.Namespaces {
-		ns = append(ns, name)
+		if err != nil {
+			return pages, err
+		}
+
+		for idx := start; idx < end; idx++ {
+			page, ok := res.Pages[titles[idx]]
+
+			if !ok {
+				continue
+			}
+
+			queries[idx] = []url.Values{pageAtParams(page.Title, at, false)}
+
+			if page.Redirect && len(page.RedirectTarget) > 0 && len(page.RedirectInterwiki) == 0 {
+				queries[idx] = append(queries[idx], pageAtParams(page.RedirectTarget, at, false))
+			}
+		}
 	}
 
-	return ns, nil
+	snapshots := make([]*PageSnapshot, len(titles))
+	err := parallel(len(titles), pageAtConcurrency, func(idx int) (err error) {
+		if len(queries[idx]) == 0 {
+			return nil
+		}
+
+		snapshots[idx], err = cl.pageAt(ctx, queries[idx])
+
+		if err == ErrPageNotFound {
+			return nil
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return pages, err
+	}
+
+	for idx, page := range snapshots {
+		if page != nil {
+			pages[titles[idx]] = *page
+		}
+	}
+
+	return pages, nil
+}
+
+// pageAt get the snapshot from the first of the revision queries that finds a revision.
+func (cl *Client) pageAt(ctx context.Context, queries []url.Values) (*PageSnapshot, error) {
+	var page *PageSnapshot
+
+	for _, params := range queries {
+		res, err := cl.revisions(ctx, params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(res.Query.Pages) > 0 && len(res.Query.Pages[0].Revisions) > 0 {
+			found := res.Query.Pages[0]
+			page = &PageSnapshot{
+				PageID:   found.PageID,
+				Ns:       found.Ns,
+				Title:    found.Title,
+				Revision: found.Revisions[0],
+				Wikitext: []byte(found.Revisions[0].Content()),
+			}
+			break
+		}
+	}
+
+	if page == nil {
+		return nil, ErrPageNotFound
+	}
+
+	html, err := cl.PageHTML(ctx, page.Title, page.Revision.RevID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page.HTML = html
+	return page, nil
+}
+
+// ResolveTitleHistory follow the title through the move log and redirects to the current page.
+// Moves are followed from the first one (or the first one after Since option), so a title reused
+// by another page after the move still resolves to the page that had it first.
+func (cl *Client) ResolveTitleHistory(ctx context.Context, title string, options ...TitleHistoryOptions) (*TitleHistory, error) {
+	hist, err := cl.titleMoves(ctx, title, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := cl.PagesDataDetailed(ctx, []string{hist.lastTitle()}, PageDataOptions{Props: []string{PageDataPropInfo}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hist, hist.resolve(res.Pages)
+}
+
+// ResolveTitleHistories resolve titles in bulk, titles that don't resolve to existing pages are left out.
+// Move logs are queried concurrently (one title per query is all the API allows),
+// current pages are resolved in batches of 50 titles.
+func (cl *Client) ResolveTitleHistories(ctx context.Context, titles []string, options ...TitleHistoryOptions) (map[string]TitleHistory, error) {
+	result := map[string]TitleHistory{}
+	hists := make([]*TitleHistory, len(titles))
+	err := parallel(len(titles), titleHistoryConcurrency, func(idx int) (err error) {
+		hists[idx], err = cl.titleMoves(ctx, titles[idx], options)
+		return err
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	lasts := []string{}
+	requested := map[string]bool{}
+
+	for _, hist := range hists {
+		if last := hist.lastTitle(); !requested[last] {
+			requested[last] = true
+			lasts = append(lasts, last)
+		}
+	}
+
+	pages := map[string]PageData{}
+
+	for start := 0; start < len(lasts); start += titleHistoryPagesLimit {
+		end := start + titleHistoryPagesLimit
+
+		if end > len(lasts) {
+			end = len(lasts)
+		}
+
+		res, err := cl.PagesDataDetailed(ctx, lasts[start:end], PageDataOptions{Props: []string{PageDataPropInfo}})
+
+		if err != nil {
+			return result, err
+		}
+
+		for title, page := range res.Pages {
+			pages[title] = page
+		}
+	}
+
+	for _, hist := range hists {
+		if err := hist.resolve(pages); err == nil {
+			result[hist.Title] = *hist
+		}
+	}
+
+	return result, nil
+}
+
+// titleMoves follow the title through the move log, the last period holds the title after the last move.
+func (cl *Client) titleMoves(ctx context.Context, title string, options []TitleHistoryOptions) (*TitleHistory, error) {
+	opts := TitleHistoryOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	hist := &TitleHistory{Title: title}
+	current, from := title, opts.Since
+	seen := map[int]bool{}
+
+	for len(seen) < titleHistoryMovesLimit {
+		data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.TitleHistoryURL+"?"+titleHistoryParams(current, from).Encode(), nil, cl.headers)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if status != http.StatusOK {
+			return nil, fmt.Errorf(errBadRequestMsg, status, data)
+		}
+
+		res := new(logEventsResponse)
+
+		if err := json.Unmarshal(data, res); err != nil {
+			return nil, err
+		}
+
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		moved := false
+
+		for _, event := range res.Query.LogEvents {
+			if seen[event.LogID] || len(event.Params.TargetTitle) == 0 {
+				continue
+			}
+
+			seen[event.LogID] = true
+			hist.Periods = append(hist.Periods, TitlePeriod{Title: current, From: from, To: event.Timestamp})
+			current, from, moved = event.Params.TargetTitle, event.Timestamp, true
+			break
+		}
+
+		if !moved {
+			break
+		}
+	}
+
+	hist.Periods = append(hist.Periods, TitlePeriod{Title: current, From: from})
+	return hist, nil
+}
+
+// InOtherLanguages get the page in other languages of the same project, following the sitelinks of the wikidata item of the page.
+// Pages without the item (not connected to wikidata) are followed through their language links instead.
+// Result is ordered as the langs fallback chain, languages without the page are left out, so the first page is the preferred one.
+// The language of the client itself can be a part of the chain.
+func (cl *Client) InOtherLanguages(ctx context.Context, title string, langs []string, options ...InOtherLanguagesOptions) ([]LanguagePage, error) {
+	opts := InOtherLanguagesOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	reg := opts.Registry
+
+	if reg == nil {
+		reg = cl.defaultRegistry()
+	}
+
+	site, err := reg.Site(ctx, cl.url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := cl.PageData(ctx, title, PageDataOptions{Props: []string{PageDataPropInfo, PageDataPropPageProps, PageDataPropLangLinks}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := map[string]PageDataLangLink{}
+
+	if item := page.Pageprops.WikibaseItem; len(item) > 0 {
+		links, err = itemLangLinks(ctx, reg, item, site.Code)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, link := range page.LangLinks {
+			links[link.Lang] = link
+		}
+	}
+
+	links[site.Lang] = PageDataLangLink{Lang: site.Lang, Title: page.Title, URL: page.FullURL}
+	pages := make([]*LanguagePage, len(langs))
+	duplicate := make([]bool, len(langs))
+	requested := map[string]bool{}
+
+	for i, lang := range langs {
+		duplicate[i], requested[lang] = requested[lang], true
+	}
+
+	err = parallel(len(langs), inOtherLanguagesConcurrency, func(i int) error {
+		link, ok := links[langs[i]]
+
+		if !ok || duplicate[i] {
+			return nil
+		}
+
+		client, err := reg.ClientFor(ctx, link.Lang, site.Code)
+
+		if err == ErrSiteNotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		data, err := client.PageData(ctx, link.Title, opts.PageData)
+
+		if err == ErrPageNotFound {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		pages[i] = &LanguagePage{Lang: link.Lang, LangLink: link, Page: data}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := []LanguagePage{}
+
+	for _, page := range pages {
+		if page != nil {
+			result = append(result, *page)
+		}
+	}
+
+	return result, nil
+}
+
+// defaultRegistry get registry created from the client, shared by all the calls.
+func (cl *Client) defaultRegistry() *Registry {
+	cl.registryMu.Lock()
+	defer cl.registryMu.Unlock()
+
+	if cl.registry == nil {
+		cl.registry = NewRegistry(cl)
+	}
+
+	return cl.registry
+}
+
+// Sitematrix get all supported wikimedia projects.
+func (cl *Client) Sitematrix(ctx context.Context) (*Sitematrix, error) {
+	matrix := new(Sitematrix)
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.SitematrixURL, nil, cl.headers)
+
+	if err != nil {
+		return matrix, err
+	}
+
+	if status != http.StatusOK {
+		return matrix, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	special := new(siteMatrixSpecialResponce)
+	err = json.Unmarshal(data, special)
+
+	if err != nil {
+		return matrix, err
+	}
+
+	matrix.Count, matrix.Specials = special.Sitematrix.Count, special.Sitematrix.Specials
+	main := new(siteMatrixMainResponse)
+	_ = json.Unmarshal(data, main)
+
+	for num, project := range main.Sitematrix {
+		if num != "count" && num != "specials" {
+			matrix.Projects = append(matrix.Projects, project)
+		}
+	}
+
+	return matrix, nil
+}
+
+// Namespaces get page types called "namespaces".
+func (cl *Client) Namespaces(ctx context.Context) ([]Namespace, error) {
+	ns := []Namespace{}
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.NamespacesURL, nil, cl.headers)
+
+	if err != nil {
+		return ns, err
+	}
+
+	if status != http.StatusOK {
+		return ns, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := new(namespacesResponse)
+	err = json.Unmarshal(data, res)
+
+	if err != nil {
+		return ns, err
+	}
+
+	for _, name := range res.Query.Namespaces {
+		ns = append(ns, name)
+	}
+
+	return ns, nil
+}
+
+// Titles get title parser built from the wiki namespaces and namespace aliases.
+func (cl *Client) Titles(ctx context.Context) (*Titles, error) {
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.TitlesURL, nil, cl.headers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := new(titlesResponse)
+	err = json.Unmarshal(data, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ns := []Namespace{}
+
+	for _, name := range res.Query.Namespaces {
+		ns = append(ns, name)
+	}
+
+	return NewTitles(ns, res.Query.NamespaceAliases...), nil
+}
+
+// SiteInfo get wiki configuration, all the properties are requested if none are specified.
+// Responses are cached on the client for the site info TTL, every call returns its own copy.
+func (cl *Client) SiteInfo(ctx context.Context, props ...string) (*SiteInfo, error) {
+	if len(props) == 0 {
+		props = siteInfoProps
+	}
+
+	unique := map[string]bool{}
+
+	for _, prop := range props {
+		unique[prop] = true
+	}
+
+	props = []string{}
+
+	for prop := range unique {
+		props = append(props, prop)
+	}
+
+	sort.Strings(props)
+	key := strings.Join(props, "|")
+
+	if info, ok := cl.siteInfo.get(key); ok {
+		return info, nil
+	}
+
+	query := url.Values{
+		"action":        []string{"query"},
+		"meta":          []string{"siteinfo"},
+		"siprop":        []string{key},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+	}
+
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.SiteInfoURL+"?"+query.Encode(), nil, cl.headers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := new(siteInfoResponse)
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+
+	info := res.siteInfo()
+
+	if cl.siteInfoTTL > 0 {
+		cl.siteInfo.set(key, info, cl.siteInfoTTL)
+	}
+
+	return info, nil
+}
+
+// WikibaseEntities get wikibase entities by ids, requests are made in batches of 50 ids.
+// Result is keyed by the requested ids, redirected entities are returned under both the original and the target id.
+func (cl *Client) WikibaseEntities(ctx context.Context, ids []string, options ...WikibaseEntitiesOptions) (map[string]WikibaseEntity, error) {
+	entities := map[string]WikibaseEntity{}
+	body := url.Values{
+		"action":        []string{"wbgetentities"},
+		"redirects":     []string{"yes"},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+	}
+
+	for _, opt := range options {
+		if len(opt.Props) > 0 {
+			body.Set("props", strings.Join(opt.Props, "|"))
+		}
+
+		if len(opt.Languages) > 0 {
+			body.Set("languages", strings.Join(opt.Languages, "|"))
+		}
+
+		if opt.LanguageFallback {
+			body.Set("languagefallback", "1")
+		}
+
+		if len(opt.Sites) > 0 {
+			body.Set("sitefilter", strings.Join(opt.Sites, "|"))
+		}
+	}
+
+	for start := 0; start < len(ids); start += wikibaseEntitiesLimit {
+		end := start + wikibaseEntitiesLimit
+
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		body.Set("ids", strings.Join(ids[start:end], "|"))
+		data, status, err := req(
+			ctx,
+			cl.httpClient,
+			http.MethodPost,
+			cl.url+cl.options.WikibaseURL,
+			strings.NewReader(body.Encode()),
+			map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			}, cl.headers)
+
+		if err != nil {
+			return entities, err
+		}
+
+		if status != http.StatusOK {
+			return entities, fmt.Errorf(errBadRequestMsg, status, data)
+		}
+
+		res := new(wikibaseEntitiesResponse)
+
+		if err := json.Unmarshal(data, res); err != nil {
+			return entities, err
+		}
+
+		if res.Error != nil {
+			return entities, res.Error
+		}
+
+		for id, entity := range res.Entities {
+			entities[id] = entity
+
+			if entity.Redirects != nil {
+				entities[entity.Redirects.From] = entity
+			}
+		}
+	}
+
+	return entities, nil
+}
+
+// SearchEntities search entities of the type ("item", "property", "lexeme", "form", "sense")
+// by label or alias in the language, use Continue of the result to get the next page.
+func (cl *Client) SearchEntities(ctx context.Context, text string, lang string, entityType string, options ...WikibaseSearchOptions) (*WikibaseSearch, error) {
+	query := url.Values{
+		"action":        []string{"wbsearchentities"},
+		"search":        []string{text},
+		"language":      []string{lang},
+		"type":          []string{entityType},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+	}
+
+	for _, opt := range options {
+		if opt.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opt.Limit))
+		}
+
+		if opt.Continue > 0 {
+			query.Set("continue", strconv.Itoa(opt.Continue))
+		}
+
+		if opt.StrictLanguage {
+			query.Set("strictlanguage", "1")
+		}
+
+		if len(opt.UseLang) > 0 {
+			query.Set("uselang", opt.UseLang)
+		}
+	}
+
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.WikibaseURL+"?"+query.Encode(), nil, cl.headers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := new(wikibaseSearchResponse)
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	return &WikibaseSearch{
+		Results:  res.Search,
+		Continue: res.SearchContinue,
+	}, nil
+}
+
+// SPARQL get query service client sharing http client and headers (including User-Agent) with this client.
+// Empty endpoint defaults to Wikidata Query Service.
+func (cl *Client) SPARQL(endpoint string) *sparql.Client {
+	if len(endpoint) == 0 {
+		endpoint = sparql.DefaultEndpoint
+	}
+
+	return sparql.NewClient(endpoint, cl.httpClient, cl.headers)
+}
+
+// PageviewsPerArticle get number of views of the article between start and end (inclusive).
+func (cl *Client) PageviewsPerArticle(ctx context.Context, title string, start time.Time, end time.Time, options ...AnalyticsOptions) ([]Pageviews, error) {
+	opts := cl.analyticsOptions(AccessAll, options)
+	res := new(pageviewsResponse)
+	path := analyticsPath(
+		"pageviews", "per-article", url.PathEscape(opts.Project), opts.Access, opts.Agent, escapeTitle(title), opts.Granularity,
+		start.UTC().Format(analyticsHourFormat), end.UTC().Format(analyticsHourFormat),
+	)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	return res.Items, nil
+}
+
+// PageviewsAggregate get number of views of the whole project between start and end (inclusive).
+func (cl *Client) PageviewsAggregate(ctx context.Context, start time.Time, end time.Time, options ...AnalyticsOptions) ([]Pageviews, error) {
+	opts := cl.analyticsOptions(AccessAll, options)
+	res := new(pageviewsResponse)
+	path := analyticsPath(
+		"pageviews", "aggregate", url.PathEscape(opts.Project), opts.Access, opts.Agent, opts.Granularity,
+		start.UTC().Format(analyticsHourFormat), end.UTC().Format(analyticsHourFormat),
+	)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	return res.Items, nil
+}
+
+// TopArticles get most viewed articles of the day, or of the month with monthly granularity.
+func (cl *Client) TopArticles(ctx context.Context, date time.Time, options ...AnalyticsOptions) ([]TopArticle, error) {
+	opts := cl.analyticsOptions(AccessAll, options)
+	res := new(topArticlesResponse)
+	date = date.UTC()
+	day := date.Format("02")
+
+	if opts.Granularity == GranularityMonthly {
+		day = "all-days"
+	}
+
+	path := analyticsPath("pageviews", "top", url.PathEscape(opts.Project), opts.Access, date.Format("2006"), date.Format("01"), day)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Items) == 0 {
+		return nil, ErrEmptyResult
+	}
+
+	return res.Items[0].Articles, nil
+}
+
+// UniqueDevices get estimated number of unique devices visiting the project between start and end (inclusive).
+func (cl *Client) UniqueDevices(ctx context.Context, start time.Time, end time.Time, options ...AnalyticsOptions) ([]UniqueDevices, error) {
+	opts := cl.analyticsOptions(AccessSiteAll, options)
+	res := new(uniqueDevicesResponse)
+	path := analyticsPath(
+		"unique-devices", url.PathEscape(opts.Project), opts.Access, opts.Granularity,
+		start.UTC().Format(analyticsDayFormat), end.UTC().Format(analyticsDayFormat),
+	)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	return res.Items, nil
+}
+
+// Editors get number of editors of the project between start (inclusive) and end (exclusive).
+func (cl *Client) Editors(ctx context.Context, start time.Time, end time.Time, options ...AnalyticsOptions) ([]EditorsCount, error) {
+	opts := cl.analyticsOptions(AccessAll, options)
+	res := new(editorsResponse)
+	path := analyticsPath(
+		"editors", "aggregate", url.PathEscape(opts.Project), opts.EditorType, opts.PageType, opts.ActivityLevel, opts.Granularity,
+		start.UTC().Format(analyticsDayFormat), end.UTC().Format(analyticsDayFormat),
+	)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	counts := []EditorsCount{}
+
+	for _, item := range res.Items {
+		counts = append(counts, item.Results...)
+	}
+
+	return counts, nil
+}
+
+// Edits get number of edits of the project between start (inclusive) and end (exclusive).
+func (cl *Client) Edits(ctx context.Context, start time.Time, end time.Time, options ...AnalyticsOptions) ([]EditsCount, error) {
+	opts := cl.analyticsOptions(AccessAll, options)
+	res := new(editsResponse)
+	path := analyticsPath(
+		"edits", "aggregate", url.PathEscape(opts.Project), opts.EditorType, opts.PageType, opts.Granularity,
+		start.UTC().Format(analyticsDayFormat), end.UTC().Format(analyticsDayFormat),
+	)
+
+	if err := cl.analytics(ctx, path, res); err != nil {
+		return nil, err
+	}
+
+	counts := []EditsCount{}
+
+	for _, item := range res.Items {
+		counts = append(counts, item.Results...)
+	}
+
+	return counts, nil
+}
+
+func (cl *Client) analyticsOptions(access string, options []AnalyticsOptions) AnalyticsOptions {
+	opts := AnalyticsOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	opts.defaults(analyticsProject(cl.url), access)
+	return opts
+}
+
+// analytics get analytics API response, analytics API answers with 404 when there's no data for the period.
+func (cl *Client) analytics(ctx context.Context, path string, res interface{}) error {
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.options.AnalyticsURL+path, nil, cl.headers)
+
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusNotFound {
+		return ErrEmptyResult
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	return json.Unmarshal(data, res)
+}
+
+// Score get model scores for revisions from Lift Wing or ORES, useful for revisions without inline scores.
+// Model is one of ScoreModel* constants, the wiki is taken from site info ("enwiki-damaging").
+// ScoreURL ending with "/v3/scores" is queried as ORES v3 API in batches of 50 revisions,
+// otherwise it's Lift Wing models URL queried for every revision, a few revisions at once.
+// Result is keyed by revision id, only the field of the requested model is filled.
+func (cl *Client) Score(ctx context.Context, model string, revids []int) (map[int]PageDataOresScores, error) {
+	if !scoreModels[model] {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedModel, model)
+	}
+
+	info, err := cl.SiteInfo(ctx, SiteInfoPropGeneral)
+
+	if err != nil {
+		return nil, err
+	}
+
+	wiki := info.General.WikiID
+	base := strings.TrimSuffix(cl.options.ScoreURL, "/")
+	results := make([]PageDataOresScores, len(revids))
+
+	if strings.HasSuffix(base, scoreORESPath) {
+		for start := 0; start < len(revids); start += scoreORESRevisionsLimit {
+			end := start + scoreORESRevisionsLimit
+
+			if end > len(revids) {
+				end = len(revids)
+			}
+
+			query := url.Values{
+				"models": []string{model},
+				"revids": []string{joinIDs(revids[start:end])},
+			}
+			res, err := cl.score(ctx, http.MethodGet, fmt.Sprintf("%s/%s/?%s", base, wiki, query.Encode()), nil)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for idx := start; idx < end; idx++ {
+				if results[idx], err = res.scores(wiki, model, revids[idx]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else {
+		reqURL := fmt.Sprintf("%s/%s-%s:predict", base, wiki, model)
+		err = parallel(len(revids), scoreConcurrency, func(idx int) error {
+			body, err := json.Marshal(scoreRequest{revids[idx]})
+
+			if err != nil {
+				return err
+			}
+
+			res, err := cl.score(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+
+			if err != nil {
+				return err
+			}
+
+			results[idx], err = res.scores(wiki, model, revids[idx])
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scores := map[int]PageDataOresScores{}
+
+	for idx, revid := range revids {
+		scores[revid] = results[idx]
+	}
+
+	return scores, nil
+}
+
+// score make the scoring request, body is sent as JSON.
+func (cl *Client) score(ctx context.Context, method string, reqURL string, body io.Reader) (scoreResponse, error) {
+	data, status, err := req(
+		ctx,
+		cl.httpClient,
+		method,
+		reqURL,
+		body,
+		map[string]string{
+			"Content-Type": "application/json",
+		}, cl.headers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := scoreResponse{}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CSRFToken get new csrf token used for edits, the token is remembered by the client.
+// Client has to be authenticated (with cookies or authorization header) to edit as a user.
+func (cl *Client) CSRFToken(ctx context.Context) (string, error) {
+	query := url.Values{
+		"action":        []string{"query"},
+		"meta":          []string{"tokens"},
+		"type":          []string{"csrf"},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+	}
+
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, cl.url+cl.options.TokenURL+"?"+query.Encode(), nil, cl.headers)
+
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf(errBadRequestMsg, status, data)
+	}
+
+	res := new(tokensResponse)
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return "", err
+	}
+
+	if res.Error != nil {
+		return "", res.Error
+	}
+
+	cl.tokens.set(res.Query.Tokens.CSRFToken)
+	return res.Query.Tokens.CSRFToken, nil
+}
+
+// WikibaseEditEntity create or update entity with wbeditentity, entity without id is created
+// (as an item unless the type is set). Labels, descriptions, aliases, sitelinks and claims are sent,
+// for lexemes also lemmas, language, lexical category, forms and senses.
+func (cl *Client) WikibaseEditEntity(ctx context.Context, entity *WikibaseEntity, options ...WikibaseEditEntityOptions) (*WikibaseEntity, error) {
+	opts := WikibaseEditEntityOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	data, err := wikibaseEntityData(entity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := url.Values{
+		"action": []string{"wbeditentity"},
+		"data":   []string{string(data)},
+	}
+
+	if len(entity.ID) > 0 {
+		body.Set("id", entity.ID)
+	} else if len(entity.Type) > 0 {
+		body.Set("new", entity.Type)
+	} else {
+		body.Set("new", "item")
+	}
+
+	if opts.Clear {
+		body.Set("clear", "1")
+	}
+
+	res, err := cl.wikibaseEdit(ctx, body, opts.WikibaseEditOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// wbeditentity returns the whole entity, it's fetched only if the response doesn't have it
+	if res.Entity != nil && len(res.Entity.ID) > 0 {
+		if res.PageInfo.LastRevID > 0 {
+			res.Entity.LastRevID = res.PageInfo.LastRevID
+		}
+
+		return res.Entity, nil
+	}
+
+	if len(entity.ID) == 0 {
+		return nil, ErrEmptyResult
+	}
+
+	return cl.wikibaseEntityAt(ctx, entity.ID, res.revision())
+}
+
+// WikibaseSetLabel set label of the entity in the language, empty value removes the label.
+func (cl *Client) WikibaseSetLabel(ctx context.Context, id string, lang string, value string, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	return cl.wikibaseEditEntity(ctx, id, url.Values{
+		"action":   []string{"wbsetlabel"},
+		"id":       []string{id},
+		"language": []string{lang},
+		"value":    []string{value},
+	}, options)
+}
+
+// WikibaseSetDescription set description of the entity in the language, empty value removes the description.
+func (cl *Client) WikibaseSetDescription(ctx context.Context, id string, lang string, value string, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	return cl.wikibaseEditEntity(ctx, id, url.Values{
+		"action":   []string{"wbsetdescription"},
+		"id":       []string{id},
+		"language": []string{lang},
+		"value":    []string{value},
+	}, options)
+}
+
+// WikibaseSetAliases add, remove or replace aliases of the entity in the language.
+func (cl *Client) WikibaseSetAliases(ctx context.Context, id string, lang string, change WikibaseAliasesChange, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	body := url.Values{
+		"action":   []string{"wbsetaliases"},
+		"id":       []string{id},
+		"language": []string{lang},
+	}
+
+	if change.Set != nil {
+		body.Set("set", strings.Join(change.Set, "|"))
+	}
+
+	if len(change.Add) > 0 {
+		body.Set("add", strings.Join(change.Add, "|"))
+	}
+
+	if len(change.Remove) > 0 {
+		body.Set("remove", strings.Join(change.Remove, "|"))
+	}
+
+	return cl.wikibaseEditEntity(ctx, id, body, options)
+}
+
+// WikibaseSetSitelink link the entity to the page on the site, empty title removes the sitelink.
+func (cl *Client) WikibaseSetSitelink(ctx context.Context, id string, site string, title string, badges []string, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	body := url.Values{
+		"action":   []string{"wbsetsitelink"},
+		"id":       []string{id},
+		"linksite": []string{site},
+	}
+
+	if len(title) > 0 {
+		body.Set("linktitle", title)
+	}
+
+	if len(badges) > 0 {
+		body.Set("badges", strings.Join(badges, "|"))
+	}
+
+	return cl.wikibaseEditEntity(ctx, id, body, options)
+}
+
+// WikibaseCreateClaim add new claim with the main snak to the entity.
+func (cl *Client) WikibaseCreateClaim(ctx context.Context, id string, snak WikibaseSnak, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	value, err := wikibaseSnakValue(&snak)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := url.Values{
+		"action":   []string{"wbcreateclaim"},
+		"entity":   []string{id},
+		"snaktype": []string{snak.SnakType},
+		"property": []string{snak.Property},
+	}
+
+	if len(value) > 0 {
+		body.Set("value", value)
+	}
+
+	return cl.wikibaseEditEntity(ctx, id, body, options)
+}
+
+// WikibaseSetClaim create or replace the whole claim, claim id ("Q42$<uuid>") is required.
+func (cl *Client) WikibaseSetClaim(ctx context.Context, claim WikibaseClaim, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	data, err := json.Marshal(claim)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.wikibaseEditEntity(ctx, wikibaseEntityID(claim.ID), url.Values{
+		"action": []string{"wbsetclaim"},
+		"claim":  []string{string(data)},
+	}, options)
+}
+
+// WikibaseRemoveClaims remove claims by their ids, all the claims have to belong to the same entity.
+func (cl *Client) WikibaseRemoveClaims(ctx context.Context, guids []string, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	if len(guids) == 0 {
+		return nil, ErrEmptyResult
+	}
+
+	return cl.wikibaseEditEntity(ctx, wikibaseEntityID(guids[0]), url.Values{
+		"action": []string{"wbremoveclaims"},
+		"claim":  []string{strings.Join(guids, "|")},
+	}, options)
+}
+
+// WikibaseSetReference add reference to the claim, reference with hash replaces the existing one.
+func (cl *Client) WikibaseSetReference(ctx context.Context, guid string, ref WikibaseReference, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	snaks, err := json.Marshal(ref.Snaks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := url.Values{
+		"action":    []string{"wbsetreference"},
+		"statement": []string{guid},
+		"snaks":     []string{string(snaks)},
+	}
+
+	if len(ref.SnaksOrder) > 0 {
+		order, err := json.Marshal(ref.SnaksOrder)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body.Set("snaks-order", string(order))
+	}
+
+	if len(ref.Hash) > 0 {
+		body.Set("reference", ref.Hash)
+	}
+
+	return cl.wikibaseEditEntity(ctx, wikibaseEntityID(guid), body, options)
+}
+
+// WikibaseSetQualifier add qualifier to the claim, snak with hash replaces the existing qualifier.
+func (cl *Client) WikibaseSetQualifier(ctx context.Context, guid string, snak WikibaseSnak, options ...WikibaseEditOptions) (*WikibaseEntity, error) {
+	value, err := wikibaseSnakValue(&snak)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := url.Values{
+		"action":   []string{"wbsetqualifier"},
+		"claim":    []string{guid},
+		"snaktype": []string{snak.SnakType},
+		"property": []string{snak.Property},
+	}
+
+	if len(value) > 0 {
+		body.Set("value", value)
+	}
+
+	if len(snak.Hash) > 0 {
+		body.Set("snakhash", snak.Hash)
+	}
+
+	return cl.wikibaseEditEntity(ctx, wikibaseEntityID(guid), body, options)
+}
+
+// wikibaseEditEntity make the edit and get the whole entity at the revision created by it.
+func (cl *Client) wikibaseEditEntity(ctx context.Context, id string, body url.Values, options []WikibaseEditOptions) (*WikibaseEntity, error) {
+	opts := WikibaseEditOptions{}
+
+	for _, opt := range options {
+		opts = opt
+	}
+
+	res, err := cl.wikibaseEdit(ctx, body, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.wikibaseEntityAt(ctx, id, res.revision())
+}
+
+// wikibaseEntityAt get entity at the revision (the latest one for zero revision) from Special:EntityData,
+// the revision is read as is, so there's no replica lag. Errors are reported as WikibaseFetchError, the edit is already made.
+func (cl *Client) wikibaseEntityAt(ctx context.Context, id string, rev int) (*WikibaseEntity, error) {
+	reqURL := cl.url + fmt.Sprintf(cl.options.EntityDataURL, url.PathEscape(strings.ToUpper(id)))
+
+	if rev > 0 {
+		reqURL += "?" + url.Values{"revision": []string{strconv.Itoa(rev)}}.Encode()
+	}
+
+	fail := func(err error) error {
+		return &WikibaseFetchError{ID: id, RevID: rev, Err: err}
+	}
+
+	data, status, err := req(ctx, cl.httpClient, http.MethodGet, reqURL, nil, cl.headers)
+
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fail(fmt.Errorf(errBadRequestMsg, status, data))
+	}
+
+	res := new(entityDataResponse)
+
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil, fail(err)
+	}
+
+	for key, entity := range res.Entities {
+		if strings.EqualFold(key, id) {
+			return &entity, nil
+		}
+	}
+
+	return nil, fail(ErrEmptyResult)
+}
+
+// wikibaseEdit post the edit with csrf token, expired token is renewed once.
+func (cl *Client) wikibaseEdit(ctx context.Context, body url.Values, opts WikibaseEditOptions) (*wikibaseEditResponse, error) {
+	body.Set("format", "json")
+	body.Set("formatversion", "2")
+
+	if opts.BaseRevID > 0 {
+		body.Set("baserevid", strconv.Itoa(opts.BaseRevID))
+	}
+
+	if len(opts.Summary) > 0 {
+		body.Set("summary", opts.Summary)
+	}
+
+	if len(opts.Tags) > 0 {
+		body.Set("tags", strings.Join(opts.Tags, "|"))
+	}
+
+	if opts.Bot {
+		body.Set("bot", "1")
+	}
+
+	for attempt := 0; ; attempt++ {
+		token := cl.tokens.get()
+
+		if len(token) == 0 {
+			var err error
+
+			if token, err = cl.CSRFToken(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body.Set("token", token)
+		data, status, err := req(
+			ctx,
+			cl.httpClient,
+			http.MethodPost,
+			cl.url+cl.options.WikibaseURL,
+			strings.NewReader(body.Encode()),
+			map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			}, cl.headers)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if status != http.StatusOK {
+			return nil, fmt.Errorf(errBadRequestMsg, status, data)
+		}
+
+		res := new(wikibaseEditResponse)
+
+		if err := json.Unmarshal(data, res); err != nil {
+			return nil, err
+		}
+
+		if res.Error != nil {
+			if errors.Is(res.Error, ErrBadToken) && attempt == 0 {
+				cl.tokens.set("")
+				continue
+			}
+
+			return nil, res.Error
+		}
+
+		return res, nil
+	}
 }
 
 // Users get list of users by id.
@@ -14,3 +14,10 @@ func TestClient(t *testing.T) {
 	assert.NotNil(t, client)
 	assert.Equal(t, clientTestURL, client.url)
 }
+
+func TestClientSPARQL(t *testing.T) {
+	client := NewClient(clientTestURL)
+
+	assert.NotNil(t, client.SPARQL(""))
+	assert.NotNil(t, client.SPARQL(clientTestURL+"sparql"))
+}
@@ -0,0 +1,260 @@
+package mediawiki
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Generators supported by PagesDataFromGenerator, see https://www.mediawiki.org/wiki/API:Query#Generators for details.
+const (
+	GeneratorCategoryMembers = "categorymembers"
+	GeneratorSearch          = "search"
+	GeneratorAllPages        = "allpages"
+	GeneratorBacklinks       = "backlinks"
+	GeneratorEmbeddedIn      = "embeddedin"
+	GeneratorLinks           = "links"
+	GeneratorTemplates       = "templates"
+	GeneratorRecentChanges   = "recentchanges"
+	GeneratorPrefixSearch    = "prefixsearch"
+)
+
+// generatorPrefixes parameter prefixes of the generators.
+var generatorPrefixes = map[string]string{
+	GeneratorCategoryMembers: "gcm",
+	GeneratorSearch:          "gsr",
+	GeneratorAllPages:        "gap",
+	GeneratorBacklinks:       "gbl",
+	GeneratorEmbeddedIn:      "gei",
+	GeneratorLinks:           "gpl",
+	GeneratorTemplates:       "gtl",
+	GeneratorRecentChanges:   "grc",
+	GeneratorPrefixSearch:    "gps",
+}
+
+// GeneratorSpec list query feeding the pages into PagesDataFromGenerator.
+// Title is the category, the linked or transcluded page, or the page which links and templates are listed.
+// Search is the search text or the prefix for prefixsearch and allpages.
+// Limit is the number of pages in a batch ("max" by default), Params are passed as is ("gcmtype": "page").
+type GeneratorSpec struct {
+	Generator  string
+	Title      string
+	Search     string
+	Namespaces []int
+	Limit      int
+	Params     map[string]string
+}
+
+func (spec *GeneratorSpec) params() (url.Values, error) {
+	prefix, ok := generatorPrefixes[spec.Generator]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported generator: '%s'", spec.Generator)
+	}
+
+	params := url.Values{
+		"generator":      []string{spec.Generator},
+		prefix + "limit": []string{"max"},
+	}
+
+	if spec.Limit > 0 {
+		params.Set(prefix+"limit", strconv.Itoa(spec.Limit))
+	}
+
+	if len(spec.Title) > 0 {
+		switch spec.Generator {
+		case GeneratorLinks, GeneratorTemplates:
+			params.Set("titles", spec.Title)
+		default:
+			params.Set(prefix+"title", spec.Title)
+		}
+	}
+
+	if len(spec.Search) > 0 {
+		switch spec.Generator {
+		case GeneratorAllPages:
+			params.Set(prefix+"prefix", spec.Search)
+		default:
+			params.Set(prefix+"search", spec.Search)
+		}
+	}
+
+	if len(spec.Namespaces) > 0 {
+		namespaces := []string{}
+
+		for _, ns := range spec.Namespaces {
+			namespaces = append(namespaces, strconv.Itoa(ns))
+		}
+
+		params.Set(prefix+"namespace", strings.Join(namespaces, "|"))
+	}
+
+	for key, value := range spec.Params {
+		params.Set(key, value)
+	}
+
+	return params, nil
+}
+
+// PageDataIterator pages produced by the generator, batch by batch.
+// Prop continuation is resolved inside of the batch, so every page in the batch is complete.
+//
+//	for pages.Next() {
+//		fmt.Println(pages.Batch())
+//	}
+//
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+type PageDataIterator struct {
+	ctx     context.Context
+	client  *Client
+	params  url.Values
+	options []PageDataOptions
+	cont    map[string]string
+	done    bool
+	batch   []PageData
+	err     error
+}
+
+// Next fetch next batch of pages, false is returned when the generator is exhausted or on error.
+func (it *PageDataIterator) Next() bool {
+	it.batch = nil
+
+	for len(it.batch) == 0 && !it.done && it.err == nil {
+		it.batch, it.err = it.next()
+	}
+
+	return len(it.batch) > 0
+}
+
+// Batch get current batch of pages, in the generator order when it has one (search, prefixsearch).
+func (it *PageDataIterator) Batch() []PageData {
+	return it.batch
+}
+
+// Err get error that stopped the iteration.
+func (it *PageDataIterator) Err() error {
+	return it.err
+}
+
+// next fetch pages until the batch is complete, merging the results of prop continuation.
+func (it *PageDataIterator) next() ([]PageData, error) {
+	pages := []PageData{}
+	lookup := map[string]int{}
+
+	for {
+		params := url.Values{}
+
+		for key, value := range it.params {
+			params[key] = value
+		}
+
+		for key, value := range it.cont {
+			params.Set(key, value)
+		}
+
+		res, err := it.client.pagesData(it.ctx, params, it.options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range res.Query.Pages {
+			if idx, ok := lookup[page.Title]; ok {
+				mergePageData(&pages[idx], &page)
+			} else {
+				lookup[page.Title] = len(pages)
+				pages = append(pages, page)
+			}
+		}
+
+		it.cont = continueParams(res.Continue)
+
+		if len(it.cont) == 0 {
+			it.done = true
+			break
+		}
+
+		if res.Batchcomplete {
+			break
+		}
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pages[i].Index < pages[j].Index
+	})
+
+	return pages, nil
+}
+
+// continueParams convert continue object of the response into request parameters.
+func continueParams(cont map[string]interface{}) map[string]string {
+	params := map[string]string{}
+
+	for key, value := range cont {
+		// numeric offsets are decoded as float64, fmt would print large ones in exponent form
+		if number, ok := value.(float64); ok {
+			params[key] = strconv.FormatFloat(number, 'f', -1, 64)
+		} else {
+			params[key] = fmt.Sprint(value)
+		}
+	}
+
+	return params
+}
+
+// hasContinuation check if any prop is left to continue, revisions continuation is ignored when skipRevisions is set.
+func hasContinuation(cont map[string]string, skipRevisions bool) bool {
+	for key := range cont {
+		if key != "continue" && (!skipRevisions || key != "rvcontinue") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergePageData append prop values of the continued response to the page.
+func mergePageData(dst *PageData, src *PageData) {
+	dst.Revisions = append(dst.Revisions, src.Revisions...)
+	dst.Categories = append(dst.Categories, src.Categories...)
+	dst.Templates = append(dst.Templates, src.Templates...)
+	dst.Redirects = append(dst.Redirects, src.Redirects...)
+	dst.Links = append(dst.Links, src.Links...)
+	dst.ExtLinks = append(dst.ExtLinks, src.ExtLinks...)
+	dst.Images = append(dst.Images, src.Images...)
+	dst.LangLinks = append(dst.LangLinks, src.LangLinks...)
+	dst.IWLinks = append(dst.IWLinks, src.IWLinks...)
+	dst.Contributors = append(dst.Contributors, src.Contributors...)
+	dst.LinksHere = append(dst.LinksHere, src.LinksHere...)
+	dst.TranscludedIn = append(dst.TranscludedIn, src.TranscludedIn...)
+
+	for qid, usage := range src.WbEntityUsage {
+		if dst.WbEntityUsage == nil {
+			dst.WbEntityUsage = PageDataWebEntityUsage{}
+		}
+
+		dst.WbEntityUsage[qid] = usage
+	}
+
+	if dst.Thumbnail == nil {
+		dst.Thumbnail = src.Thumbnail
+	}
+
+	if dst.Original == nil {
+		dst.Original = src.Original
+	}
+
+	if len(dst.Description) == 0 {
+		dst.Description, dst.DescriptionSource = src.Description, src.DescriptionSource
+	}
+
+	// total of the page, repeated in the continued responses
+	if dst.AnonContributors == 0 {
+		dst.AnonContributors = src.AnonContributors
+	}
+}
@@ -0,0 +1,123 @@
+package mediawiki
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const generatorTestURL = "/generator"
+const generatorTestCategory = "Category:Physicists"
+
+func createGeneratorServer(t *testing.T) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(generatorTestURL, func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, GeneratorCategoryMembers, r.Form.Get("generator"))
+		assert.Equal(t, generatorTestCategory, r.Form.Get("gcmtitle"))
+		assert.Equal(t, "0|14", r.Form.Get("gcmnamespace"))
+		assert.Equal(t, "2", r.Form.Get("gcmlimit"))
+		assert.Equal(t, "page", r.Form.Get("gcmtype"))
+		res := ""
+
+		switch {
+		case r.Form.Get("gcmcontinue") == "" && r.Form.Get("clcontinue") == "":
+			res = `{
+				"continue": {"clcontinue": "736|Physicists", "gcmcontinue": "page|EINSTEIN|736", "continue": "gcmcontinue||"},
+				"query": {"pages": [
+					{"pageid": 736, "ns": 0, "title": "Albert Einstein", "categories": [{"ns": 14, "title": "Category:German physicists"}]},
+					{"pageid": 737, "ns": 0, "title": "Niels Bohr"}
+				]}
+			}`
+		case r.Form.Get("clcontinue") == "736|Physicists":
+			res = `{
+				"batchcomplete": true,
+				"continue": {"gcmcontinue": "page|EINSTEIN|736", "continue": "gcmcontinue||"},
+				"query": {"pages": [
+					{"pageid": 736, "ns": 0, "title": "Albert Einstein", "categories": [{"ns": 14, "title": "Category:Physicists"}]},
+					{"pageid": 737, "ns": 0, "title": "Niels Bohr", "categories": [{"ns": 14, "title": "Category:Danish physicists"}]}
+				]}
+			}`
+		case r.Form.Get("gcmcontinue") == "page|EINSTEIN|736":
+			res = `{
+				"batchcomplete": true,
+				"query": {"pages": [
+					{"pageid": 738, "ns": 0, "title": "Max Planck"}
+				]}
+			}`
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	return router
+}
+
+func TestPagesDataFromGenerator(t *testing.T) {
+	srv := httptest.NewServer(createGeneratorServer(t))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = generatorTestURL
+	ctx := context.Background()
+
+	pages := client.PagesDataFromGenerator(ctx, GeneratorSpec{
+		Generator:  GeneratorCategoryMembers,
+		Title:      generatorTestCategory,
+		Namespaces: []int{0, 14},
+		Limit:      2,
+		Params:     map[string]string{"gcmtype": "page"},
+	}, PageDataOptions{Props: []string{PageDataPropCategories}})
+
+	assert.True(t, pages.Next())
+	batch := pages.Batch()
+	assert.Len(t, batch, 2)
+	assert.Equal(t, "Albert Einstein", batch[0].Title)
+	assert.Len(t, batch[0].Categories, 2)
+	assert.Len(t, batch[1].Categories, 1)
+
+	assert.True(t, pages.Next())
+	assert.Len(t, pages.Batch(), 1)
+	assert.Equal(t, "Max Planck", pages.Batch()[0].Title)
+
+	assert.False(t, pages.Next())
+	assert.NoError(t, pages.Err())
+
+	pages = client.PagesDataFromGenerator(ctx, GeneratorSpec{Generator: "watchlist"})
+	assert.False(t, pages.Next())
+	assert.Error(t, pages.Err())
+}
+
+func TestGeneratorSpec(t *testing.T) {
+	params, err := (&GeneratorSpec{Generator: GeneratorLinks, Title: "Albert Einstein"}).params()
+	assert.NoError(t, err)
+	assert.Equal(t, "Albert Einstein", params.Get("titles"))
+	assert.Equal(t, "max", params.Get("gpllimit"))
+
+	params, err = (&GeneratorSpec{Generator: GeneratorPrefixSearch, Search: "Einst"}).params()
+	assert.NoError(t, err)
+	assert.Equal(t, "Einst", params.Get("gpssearch"))
+
+	params, err = (&GeneratorSpec{Generator: GeneratorAllPages, Search: "Einst"}).params()
+	assert.NoError(t, err)
+	assert.Equal(t, "Einst", params.Get("gapprefix"))
+}
+
+func TestContinueParams(t *testing.T) {
+	cont := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"gsroffset": 1000000, "gpsoffset": 20, "continue": "gsroffset||"}`), &cont))
+
+	assert.Equal(t, map[string]string{
+		"gsroffset": "1000000",
+		"gpsoffset": "20",
+		"continue":  "gsroffset||",
+	}, continueParams(cont))
+}
@@ -1,5 +1,15 @@
 module github.com/protsack-stephan/mediawiki-api-client
 
-go 1.15
+go 1.18
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/stretchr/testify v1.6.1
+	golang.org/x/net v0.6.0
+	golang.org/x/text v0.13.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
@@ -0,0 +1,58 @@
+package mediawiki
+
+import "context"
+
+// Max number of pages in other languages fetched at once.
+const inOtherLanguagesConcurrency = 10
+
+// Database name of the wikibase repository holding the items of the pages.
+const inOtherLanguagesRepository = "wikidatawiki"
+
+// InOtherLanguagesOptions optional parameters for InOtherLanguages method.
+// Registry provides clients for the other wikis (the registry of the client by default), PageData is used for the fetched pages.
+type InOtherLanguagesOptions struct {
+	Registry *Registry
+	PageData PageDataOptions
+}
+
+// LanguagePage page in one of the requested languages.
+type LanguagePage struct {
+	Lang     string
+	LangLink PageDataLangLink
+	Page     PageData
+}
+
+// itemLangLinks get sitelinks of the item to the wikis of the project, keyed by the language of the wiki.
+func itemLangLinks(ctx context.Context, reg *Registry, item string, project string) (map[string]PageDataLangLink, error) {
+	repo, err := reg.Client(ctx, inOtherLanguagesRepository)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := repo.WikibaseEntities(ctx, []string{item}, WikibaseEntitiesOptions{Props: []string{"sitelinks/urls"}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := map[string]PageDataLangLink{}
+
+	for dbname, sitelink := range entities[item].Sitelinks {
+		site, err := reg.Site(ctx, dbname)
+
+		if err == ErrSiteNotFound {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if site.Code == project && !site.Special {
+			links[site.Lang] = PageDataLangLink{Lang: site.Lang, Title: sitelink.Title, URL: sitelink.URL}
+		}
+	}
+
+	return links, nil
+}
@@ -0,0 +1,102 @@
+package mediawiki
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const languagesTestSitematrixURL = "/languages-sitematrix"
+const languagesTestPageDataURL = "/languages-page-data"
+const languagesTestWikibaseURL = "/languages-wikibase"
+
+func createLanguagesServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(languagesTestSitematrixURL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sitematrix": {
+			"count": 2,
+			"0": {"code": "en", "name": "English", "site": [{"url": "http://en.wikipedia.test", "dbname": "enwiki", "code": "wiki", "sitename": "Wikipedia"}]},
+			"1": {"code": "de", "name": "Deutsch", "site": [{"url": "http://de.wikipedia.test", "dbname": "dewiki", "code": "wiki", "sitename": "Wikipedia"}]},
+			"specials": [{"url": "http://www.wikidata.test", "dbname": "wikidatawiki", "code": "wikidata", "sitename": "Wikidata"}]
+		}}`))
+	})
+
+	router.HandleFunc(languagesTestPageDataURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		res := ""
+
+		switch {
+		case r.Host == "en.wikipedia.test" && r.Form.Get("titles") == "Albert Einstein":
+			res = `{"query": {"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein", "fullurl": "https://en.wikipedia.org/wiki/Albert_Einstein",
+				"pageprops": {"wikibase_item": "Q937"},
+				"langlinks": [{"lang": "de", "title": "Stale link", "url": "https://de.wikipedia.org/wiki/Stale_link"}]
+			}]}}`
+		case r.Host == "en.wikipedia.test" && r.Form.Get("titles") == "Local page":
+			res = `{"query": {"pages": [{"pageid": 10, "ns": 0, "title": "Local page", "langlinks": [
+				{"lang": "de", "title": "Albert Einstein (Physiker)", "url": "https://de.wikipedia.org/wiki/Albert_Einstein_(Physiker)"}
+			]}]}}`
+		case r.Host == "de.wikipedia.test" && r.Form.Get("titles") == "Albert Einstein (Physiker)":
+			res = `{"query": {"pages": [{"pageid": 1278360, "ns": 0, "title": "Albert Einstein (Physiker)"}]}}`
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	router.HandleFunc(languagesTestWikibaseURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		if r.Host != "www.wikidata.test" || r.Form.Get("ids") != "Q937" || r.Form.Get("props") != "sitelinks/urls" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"success": 1, "entities": {"Q937": {"id": "Q937", "type": "item", "sitelinks": {
+			"enwiki": {"site": "enwiki", "title": "Albert Einstein", "url": "https://en.wikipedia.org/wiki/Albert_Einstein"},
+			"dewiki": {"site": "dewiki", "title": "Albert Einstein (Physiker)", "url": "https://de.wikipedia.org/wiki/Albert_Einstein_(Physiker)"},
+			"frwiki": {"site": "frwiki", "title": "Albert Einstein", "url": "https://fr.wikipedia.org/wiki/Albert_Einstein"}
+		}}}}`))
+	})
+
+	return router
+}
+
+func TestInOtherLanguages(t *testing.T) {
+	srv := httptest.NewServer(createLanguagesServer())
+	defer srv.Close()
+
+	client := NewClient("http://en.wikipedia.test")
+	client.options.SitematrixURL = languagesTestSitematrixURL
+	client.options.PageDataURL = languagesTestPageDataURL
+	client.options.WikibaseURL = languagesTestWikibaseURL
+
+	// every wiki host is served by the test server
+	client.httpClient = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, strings.TrimPrefix(srv.URL, "http://"))
+		},
+	}}
+	ctx := context.Background()
+
+	pages, err := client.InOtherLanguages(ctx, "Albert Einstein", []string{"es", "fr", "de", "en"})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Equal(t, "de", pages[0].Lang)
+	assert.Equal(t, 1278360, pages[0].Page.PageID)
+	assert.Equal(t, "https://de.wikipedia.org/wiki/Albert_Einstein_(Physiker)", pages[0].LangLink.URL)
+	assert.Equal(t, "en", pages[1].Lang)
+	assert.Equal(t, 736, pages[1].Page.PageID)
+
+	pages, err = client.InOtherLanguages(ctx, "Local page", []string{"de", "de"})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 1)
+	assert.Equal(t, 1278360, pages[0].Page.PageID)
+}
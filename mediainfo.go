@@ -0,0 +1,74 @@
+package mediawiki
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Content model of the mediainfo slot.
+const mediaInfoContentModel = "wikibase-mediainfo"
+
+// ErrContentModel slot content can't be decoded into the requested type.
+var ErrContentModel = errors.New("unexpected content model")
+
+// MediaInfo structured data of the Commons file stored in the mediainfo slot.
+type MediaInfo struct {
+	Type         string                     `json:"type"`
+	ID           string                     `json:"id"`
+	Labels       map[string]WikibaseTerm    `json:"labels"`
+	Descriptions map[string]WikibaseTerm    `json:"descriptions"`
+	Statements   map[string][]WikibaseClaim `json:"statements"`
+}
+
+// Label get caption in the first available language.
+func (mi *MediaInfo) Label(langs ...string) string {
+	return wikibaseTerm(mi.Labels, langs)
+}
+
+// UnmarshalJSON decode mediainfo, empty labels, descriptions and statements are stored as lists.
+func (mi *MediaInfo) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Type         string          `json:"type"`
+		ID           string          `json:"id"`
+		Labels       json.RawMessage `json:"labels"`
+		Descriptions json.RawMessage `json:"descriptions"`
+		Statements   json.RawMessage `json:"statements"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	mi.Type, mi.ID = raw.Type, raw.ID
+
+	for _, field := range []struct {
+		data  json.RawMessage
+		value interface{}
+	}{
+		{raw.Labels, &mi.Labels},
+		{raw.Descriptions, &mi.Descriptions},
+		{raw.Statements, &mi.Statements},
+	} {
+		if !bytes.HasPrefix(bytes.TrimSpace(field.data), []byte("{")) {
+			continue
+		}
+
+		if err := json.Unmarshal(field.data, field.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MediaInfo decode content of the mediainfo slot.
+func (slot *PageDataRevisionSlot) MediaInfo() (*MediaInfo, error) {
+	if slot.Contentmodel != mediaInfoContentModel {
+		return nil, fmt.Errorf("%w: %s", ErrContentModel, slot.Contentmodel)
+	}
+
+	info := new(MediaInfo)
+	return info, json.Unmarshal([]byte(slot.Content), info)
+}
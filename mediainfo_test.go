@@ -0,0 +1,46 @@
+package mediawiki
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mediaInfoTestContent = `{
+	"type": "mediainfo",
+	"id": "M68172839",
+	"labels": {"en": {"language": "en", "value": "Douglas Adams portrait"}},
+	"descriptions": [],
+	"statements": {
+		"P180": [{
+			"id": "M68172839$1f9a3c7e-4e2a-4b3f-9e3a-2c1b8a7d6e5f",
+			"type": "statement",
+			"rank": "preferred",
+			"mainsnak": {
+				"snaktype": "value",
+				"property": "P180",
+				"datavalue": {"type": "wikibase-entityid", "value": {"entity-type": "item", "numeric-id": 42, "id": "Q42"}}
+			}
+		}]
+	}
+}`
+
+func TestMediaInfo(t *testing.T) {
+	slot := PageDataRevisionSlot{
+		Contentmodel: mediaInfoContentModel,
+		Content:      mediaInfoTestContent,
+	}
+
+	info, err := slot.MediaInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "M68172839", info.ID)
+	assert.Equal(t, "Douglas Adams portrait", info.Label("de", "en"))
+	assert.Empty(t, info.Descriptions)
+	assert.Len(t, info.Statements["P180"], 1)
+	assert.Equal(t, "Q42", info.Statements["P180"][0].MainSnak.DataValue.EntityID.ID)
+
+	slot.Contentmodel = "wikitext"
+	_, err = slot.MediaInfo()
+	assert.True(t, errors.Is(err, ErrContentModel))
+}
@@ -10,4 +10,12 @@ type Options struct {
 	NamespacesURL    string
 	PageDataURL      string
 	UserURL          string
+	TitlesURL        string
+	SiteInfoURL      string
+	WikibaseURL      string
+	TokenURL         string
+	AnalyticsURL     string
+	ScoreURL         string
+	TitleHistoryURL  string
+	EntityDataURL    string
 }
@@ -0,0 +1,47 @@
+package mediawiki
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Max number of titles resolved by a single query and revisions queried at once by PagesAt.
+const (
+	pageAtTitlesLimit = 50
+	pageAtConcurrency = 10
+)
+
+// PageSnapshot state of the page at a point in time.
+// Title is the current title of the page, not the one it had at the time, it differs from the requested one
+// when the page was moved afterwards.
+type PageSnapshot struct {
+	PageID   int
+	Ns       int
+	Title    string
+	Revision Revision
+	Wikitext []byte
+	HTML     []byte
+}
+
+// pageAtParams query for the revision that was current at the time.
+func pageAtParams(title string, at time.Time, redirects bool) url.Values {
+	params := url.Values{
+		"action":        []string{"query"},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+		"prop":          []string{"revisions"},
+		"titles":        []string{title},
+		"rvlimit":       []string{"1"},
+		"rvdir":         []string{string(RevisionOrderingOlder)},
+		"rvstart":       []string{at.UTC().Format(time.RFC3339)},
+		"rvprop":        []string{strings.Join(append(append([]string{}, PageHistoryDefaultProps...), "content"), "|")},
+		"rvslots":       []string{SlotMain},
+	}
+
+	if redirects {
+		params.Set("redirects", "1")
+	}
+
+	return params
+}
@@ -0,0 +1,120 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pageAtTestURL = "/page-at?"
+const pageAtTestHTMLURL = "/page-at-html/"
+const pageAtTestPageDataURL = "/page-at-page-data"
+
+func createPageAtServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc("/page-at", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("rvstart") != "2010-01-01T00:00:00Z" || query.Get("rvlimit") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := ""
+
+		switch {
+		case query.Get("titles") == "Einstein" && query.Get("redirects") == "":
+			// redirect created by the move after the requested time
+			res = `{"query": {"pages": [{"pageid": 100, "ns": 0, "title": "Einstein"}]}}`
+		case query.Get("titles") == "Einstein":
+			res = `{"query": {
+				"redirects": [{"from": "Einstein", "to": "Albert Einstein"}],
+				"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein", "revisions": [
+					{"revid": 5, "timestamp": "2009-12-01T00:00:00Z", "size": 4, "slots": {"main": {"content": "five"}}}
+				]}]
+			}}`
+		case query.Get("titles") == "Albert Einstein":
+			res = `{"query": {"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein", "revisions": [
+				{"revid": 5, "timestamp": "2009-12-01T00:00:00Z", "size": 4, "slots": {"main": {"content": "five"}}}
+			]}]}}`
+		case query.Get("titles") == "Relativity":
+			res = `{"query": {"pages": [{"pageid": 737, "ns": 0, "title": "Relativity", "revisions": [
+				{"revid": 7, "timestamp": "2009-11-01T00:00:00Z", "slots": {"main": {"content": "seven"}}}
+			]}]}}`
+		default:
+			res = `{"query": {"pages": [{"ns": 0, "title": "Nowhere", "missing": true}]}}`
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	router.HandleFunc(pageAtTestPageDataURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		res := ""
+
+		switch {
+		case r.Form.Get("titles") == "Einstein|Relativity|Nowhere" && r.Form.Get("redirects") == "":
+			res = `{"query": {"pages": [
+				{"pageid": 100, "ns": 0, "title": "Einstein", "redirect": true},
+				{"pageid": 737, "ns": 0, "title": "Relativity"},
+				{"ns": 0, "title": "Nowhere", "missing": true}
+			]}}`
+		case r.Form.Get("titles") == "Einstein" && r.Form.Get("redirects") == "1":
+			res = `{"query": {
+				"redirects": [{"from": "Einstein", "to": "Albert Einstein"}],
+				"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein"}]
+			}}`
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	router.HandleFunc(pageAtTestHTMLURL+"Albert_Einstein/5", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>five</p>"))
+	})
+
+	router.HandleFunc(pageAtTestHTMLURL+"Relativity/7", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>seven</p>"))
+	})
+
+	return router
+}
+
+func TestPageAt(t *testing.T) {
+	srv := httptest.NewServer(createPageAtServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageRevisionsURL = pageAtTestURL
+	client.options.PageHTMLURL = pageAtTestHTMLURL
+	client.options.PageDataURL = pageAtTestPageDataURL
+	ctx := context.Background()
+	at := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	page, err := client.PageAt(ctx, "Einstein", at)
+	assert.NoError(t, err)
+	assert.Equal(t, "Albert Einstein", page.Title)
+	assert.Equal(t, 5, page.Revision.RevID)
+	assert.Equal(t, "five", string(page.Wikitext))
+	assert.Equal(t, "<p>five</p>", string(page.HTML))
+
+	_, err = client.PageAt(ctx, "Nowhere", at)
+	assert.True(t, errors.Is(err, ErrPageNotFound))
+
+	pages, err := client.PagesAt(ctx, []string{"Einstein", "Relativity", "Nowhere"}, at)
+	assert.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Equal(t, 5, pages["Einstein"].Revision.RevID)
+	assert.Equal(t, "Albert Einstein", pages["Einstein"].Title)
+	assert.Equal(t, 7, pages["Relativity"].Revision.RevID)
+	assert.Equal(t, "<p>seven</p>", string(pages["Relativity"].HTML))
+}
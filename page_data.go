@@ -1,19 +1,125 @@
 package mediawiki
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
 
 const pageDataURL = "/w/api.php"
 
+// PagesDataInterwiki title pointing to another wiki, RedirectChain is set when the title is a redirect to another wiki.
+type PagesDataInterwiki struct {
+	Title         string   `json:"title"`
+	Prefix        string   `json:"iw"`
+	Fragment      string   `json:"-"`
+	RedirectChain []string `json:"-"`
+}
+
+// PagesDataResult page data along with the titles that could not be resolved to existing pages, all keyed by the requested titles.
+// Missing pages are in a regular namespace and have PageData with namespace and normalized title, Invalid holds the reasons.
+type PagesDataResult struct {
+	Pages     map[string]PageData
+	Missing   map[string]PageData
+	Invalid   map[string]string
+	Special   map[string]PageData
+	Interwiki map[string]PagesDataInterwiki
+}
+
+// pageDataResolution requested title after normalization and redirects.
+type pageDataResolution struct {
+	normalized string
+	chain      []string
+	target     string
+	fragment   string
+	interwiki  string
+}
+
+// apply expose the resolution on the page, target is the page itself unless redirects are reported.
+func (rsv *pageDataResolution) apply(page *PageData) {
+	page.NormalizedTitle = rsv.normalized
+	page.RedirectChain = rsv.chain
+	page.RedirectFragment = rsv.fragment
+	page.RedirectInterwiki = rsv.interwiki
+
+	if len(rsv.chain) > 0 {
+		page.RedirectedFrom = rsv.chain[0]
+		page.RedirectTarget = rsv.target
+	}
+}
+
+// Prop modules of the page data, see https://www.mediawiki.org/wiki/API:Properties for details.
+const (
+	PageDataPropInfo          = "info"
+	PageDataPropCategories    = "categories"
+	PageDataPropRevisions     = "revisions"
+	PageDataPropTemplates     = "templates"
+	PageDataPropWbEntityUsage = "wbentityusage"
+	PageDataPropPageProps     = "pageprops"
+	PageDataPropRedirects     = "redirects"
+	PageDataPropFlagged       = "flagged"
+	PageDataPropLinks         = "links"
+	PageDataPropExtLinks      = "extlinks"
+	PageDataPropImages        = "images"
+	PageDataPropLangLinks     = "langlinks"
+	PageDataPropIWLinks       = "iwlinks"
+	PageDataPropPageImages    = "pageimages"
+	PageDataPropDescription   = "description"
+	PageDataPropContributors  = "contributors"
+	PageDataPropLinksHere     = "linkshere"
+	PageDataPropTranscludedIn = "transcludedin"
+)
+
+// PageDataDefaultProps prop modules requested when none are specified.
+var PageDataDefaultProps = []string{
+	PageDataPropInfo,
+	PageDataPropCategories,
+	PageDataPropRevisions,
+	PageDataPropTemplates,
+	PageDataPropWbEntityUsage,
+	PageDataPropPageProps,
+	PageDataPropRedirects,
+	PageDataPropFlagged,
+}
+
+// PageDataDefaultRevisionProps revision props requested when none are specified.
+var PageDataDefaultRevisionProps = []string{"comment", "oresscores", "content", "ids", "timestamp", "tags", "user", "userid", "flags"}
+
+// pageDataPropParams parameters sent along with the prop modules, limits are set to the max values.
+var pageDataPropParams = map[string]map[string]string{
+	PageDataPropInfo:          {"inprop": "displaytitle|protection|url|watchers"},
+	PageDataPropPageProps:     {"ppprop": "wikibase_item"},
+	PageDataPropLinks:         {"pllimit": "500"},
+	PageDataPropExtLinks:      {"ellimit": "500"},
+	PageDataPropImages:        {"imlimit": "500"},
+	PageDataPropLangLinks:     {"lllimit": "500", "llprop": "url|langname|autonym"},
+	PageDataPropIWLinks:       {"iwlimit": "500", "iwprop": "url"},
+	PageDataPropPageImages:    {"pilimit": "50", "piprop": "thumbnail|original|name"},
+	PageDataPropContributors:  {"pclimit": "500"},
+	PageDataPropLinksHere:     {"lhlimit": "500", "lhprop": "pageid|title|redirect"},
+	PageDataPropTranscludedIn: {"tilimit": "500", "tiprop": "pageid|title|redirect"},
+}
+
 // PageDataOptions representation of optional arguments to PagesData API.
 // For details on page category props, refer to https://www.mediawiki.org/wiki/API:Categories#API_documentation
+// Slots are the slot roles to return content for (SlotMain by default, SlotAll for all the slots).
+// Props select the prop modules (PageDataDefaultProps by default), BaseRevisionProps replace PageDataDefaultRevisionProps
+// and RevisionProps are added on top of them, for example metadata only call can leave "content" out.
+// ReportRedirects returns redirect pages themselves with the redirect chain and target instead of following them.
 type PageDataOptions struct {
-	RevisionsLimit  int
-	RevisionProps   []string
-	CategoriesLimit int
-	CategoriesProps []string
-	TemplatesLimit  int
-	RedirectsLimit  int
-	WebEntityLimits int
+	RevisionsLimit    int
+	RevisionProps     []string
+	CategoriesLimit   int
+	CategoriesProps   []string
+	TemplatesLimit    int
+	RedirectsLimit    int
+	WebEntityLimits   int
+	Slots             []string
+	Props             []string
+	BaseRevisionProps []string
+	ReportRedirects   bool
 }
 
 // PageDataOresScore representation for ORES score
@@ -24,26 +130,92 @@ type PageDataOresScore struct {
 
 // PageDataOresArticleQuality representation for ORES article quality
 type PageDataOresArticleQuality struct {
-	Stub float64 `json:"Stub"`
+	FA    float64 `json:"FA"`
+	GA    float64 `json:"GA"`
+	B     float64 `json:"B"`
+	C     float64 `json:"C"`
+	Start float64 `json:"Start"`
+	Stub  float64 `json:"Stub"`
 }
 
+// Class get the most probable article quality class, empty if there's no score.
+func (aq PageDataOresArticleQuality) Class() string {
+	return oresClass(map[string]float64{
+		"FA":    aq.FA,
+		"GA":    aq.GA,
+		"B":     aq.B,
+		"C":     aq.C,
+		"Start": aq.Start,
+		"Stub":  aq.Stub,
+	})
+}
+
+// PageDataOresDraftQuality representation for ORES draft quality
+type PageDataOresDraftQuality struct {
+	OK        float64 `json:"OK"`
+	Attack    float64 `json:"attack"`
+	Spam      float64 `json:"spam"`
+	Vandalism float64 `json:"vandalism"`
+}
+
+// Class get the most probable draft quality class, empty if there's no score.
+func (dq PageDataOresDraftQuality) Class() string {
+	return oresClass(map[string]float64{
+		"OK":        dq.OK,
+		"attack":    dq.Attack,
+		"spam":      dq.Spam,
+		"vandalism": dq.Vandalism,
+	})
+}
+
+// PageDataOresArticleTopic representation for ORES article topic, probabilities keyed by topic ("Culture.Biography.Biography*")
+type PageDataOresArticleTopic map[string]float64
+
 // PageDataOresScores representation of ORES scores
 type PageDataOresScores struct {
 	Articlequality PageDataOresArticleQuality `json:"articlequality"`
+	Articletopic   PageDataOresArticleTopic   `json:"articletopic"`
 	Damaging       PageDataOresScore          `json:"damaging"`
+	Draftquality   PageDataOresDraftQuality   `json:"draftquality"`
 	Goodfaith      PageDataOresScore          `json:"goodfaith"`
 }
 
-// PageDataRevisionMainSlot representation for revision main slot
-type PageDataRevisionMainSlot struct {
+// UnmarshalJSON decode scores, revisions without scores have an empty list instead of an object.
+func (scores *PageDataOresScores) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return nil
+	}
+
+	type oresScores PageDataOresScores
+	return json.Unmarshal(data, (*oresScores)(scores))
+}
+
+// Slot roles of the revision, "*" requests all the slots.
+const (
+	SlotMain      = "main"
+	SlotMediaInfo = "mediainfo"
+	SlotAll       = "*"
+)
+
+// PageDataRevisionSlot representation for revision slot
+type PageDataRevisionSlot struct {
 	Contentmodel  string `json:"contentmodel"`
 	Contentformat string `json:"contentformat"`
+	Size          int    `json:"size"`
+	Sha1          string `json:"sha1"`
 	Content       string `json:"content"`
+	Missing       bool   `json:"missing"`
 }
 
-// PageDataRevisionSlots representation for revision slots
-type PageDataRevisionSlots struct {
-	Main PageDataRevisionMainSlot `json:"main"`
+// PageDataRevisionMainSlot representation for revision main slot
+type PageDataRevisionMainSlot = PageDataRevisionSlot
+
+// PageDataRevisionSlots representation for revision slots, keyed by slot role
+type PageDataRevisionSlots map[string]PageDataRevisionSlot
+
+// Main get main slot of the revision.
+func (slots PageDataRevisionSlots) Main() PageDataRevisionSlot {
+	return slots[SlotMain]
 }
 
 // PageDataRevision revision data for pages data response
@@ -57,7 +229,7 @@ type PageDataRevision struct {
 	Slots      PageDataRevisionSlots `json:"slots"`
 	Comment    string                `json:"comment"`
 	Tags       []string              `json:"tags"`
-	Oresscores interface{}           `json:"oresscores"`
+	Oresscores PageDataOresScores    `json:"oresscores"`
 }
 
 // PageDataProtection representation for page data protection
@@ -105,11 +277,54 @@ type PageDataWebEntityUsage map[string]struct {
 	Aspects []string `json:"aspects"`
 }
 
+// PageDataLink representation for page data link, image, linkshere and transcludedin entries
+type PageDataLink struct {
+	PageID   int    `json:"pageid,omitempty"`
+	Ns       int    `json:"ns"`
+	Title    string `json:"title"`
+	Redirect bool   `json:"redirect,omitempty"`
+}
+
+// PageDataExtLink representation for page data external link
+type PageDataExtLink struct {
+	URL string `json:"url"`
+}
+
+// PageDataLangLink representation for page data language link
+type PageDataLangLink struct {
+	Lang     string `json:"lang"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	LangName string `json:"langname"`
+	Autonym  string `json:"autonym"`
+}
+
+// PageDataIWLink representation for page data interwiki link
+type PageDataIWLink struct {
+	Prefix string `json:"prefix"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// PageDataImage representation for page image thumbnail or original
+type PageDataImage struct {
+	Source string `json:"source"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// PageDataContributor representation for page data contributor
+type PageDataContributor struct {
+	UserID int    `json:"userid"`
+	Name   string `json:"name"`
+}
+
 // PageData page data returned from actions API
 type PageData struct {
 	PageID               int                    `json:"pageid"`
 	Ns                   int                    `json:"ns"`
 	Title                string                 `json:"title"`
+	Index                int                    `json:"index,omitempty"`
 	Watchers             int                    `json:"watchers"`
 	ContentModel         string                 `json:"contentmodel"`
 	PageLanguage         string                 `json:"pagelanguage"`
@@ -119,6 +334,10 @@ type PageData struct {
 	LastRevID            int                    `json:"lastrevid"`
 	Length               int                    `json:"length"`
 	Missing              bool                   `json:"missing"`
+	Redirect             bool                   `json:"redirect"`
+	Special              bool                   `json:"special"`
+	Invalid              bool                   `json:"invalid"`
+	InvalidReason        string                 `json:"invalidreason"`
 	Protection           []PageDataProtection   `json:"protection"`
 	Restrictiontypes     []string               `json:"restrictiontypes"`
 	FullURL              string                 `json:"fullurl"`
@@ -132,6 +351,28 @@ type PageData struct {
 	Flagged              PageDataFlagged        `json:"flagged"`
 	Pageprops            PageDataProps          `json:"pageprops"`
 	WbEntityUsage        PageDataWebEntityUsage `json:"wbentityusage"`
+	Links                []PageDataLink         `json:"links"`
+	ExtLinks             []PageDataExtLink      `json:"extlinks"`
+	Images               []PageDataLink         `json:"images"`
+	LangLinks            []PageDataLangLink     `json:"langlinks"`
+	IWLinks              []PageDataIWLink       `json:"iwlinks"`
+	Thumbnail            *PageDataImage         `json:"thumbnail"`
+	Original             *PageDataImage         `json:"original"`
+	PageImage            string                 `json:"pageimage"`
+	Description          string                 `json:"description"`
+	DescriptionSource    string                 `json:"descriptionsource"`
+	Contributors         []PageDataContributor  `json:"contributors"`
+	AnonContributors     int                    `json:"anoncontributors"`
+	LinksHere            []PageDataLink         `json:"linkshere"`
+	TranscludedIn        []PageDataLink         `json:"transcludedin"`
+
+	// resolution of the requested title, filled by PagesData
+	NormalizedTitle   string   `json:"-"`
+	RedirectedFrom    string   `json:"-"`
+	RedirectChain     []string `json:"-"`
+	RedirectTarget    string   `json:"-"`
+	RedirectFragment  string   `json:"-"`
+	RedirectInterwiki string   `json:"-"`
 }
 
 type pageDataResponse struct {
@@ -144,9 +385,67 @@ type pageDataResponse struct {
 			To          string `json:"to"`
 		} `json:"normalized"`
 		Redirects []struct {
-			From string `json:"from"`
-			To   string `json:"to"`
+			From        string `json:"from"`
+			To          string `json:"to"`
+			ToFragment  string `json:"tofragment"`
+			ToInterwiki string `json:"tointerwiki"`
 		} `json:"redirects"`
-		Pages []PageData `json:"pages"`
+		Interwiki []PagesDataInterwiki `json:"interwiki"`
+		Pages     []PageData           `json:"pages"`
 	} `json:"query"`
 }
+
+// resolve follow the requested title through normalization and the chain of redirects.
+func (res *pageDataResponse) resolve(title string) *pageDataResolution {
+	rsv := &pageDataResolution{
+		normalized: title,
+		chain:      []string{},
+	}
+
+	for _, norm := range res.Query.Normalized {
+		if norm.From == title {
+			rsv.normalized = norm.To
+		}
+	}
+
+	rsv.target = rsv.normalized
+	visited := map[string]bool{}
+
+	for !visited[rsv.target] && len(rsv.interwiki) == 0 {
+		visited[rsv.target] = true
+
+		for _, rdr := range res.Query.Redirects {
+			if rdr.From == rsv.target {
+				rsv.chain = append(rsv.chain, rdr.From)
+				rsv.target, rsv.fragment, rsv.interwiki = rdr.To, rdr.ToFragment, rdr.ToInterwiki
+				break
+			}
+		}
+	}
+
+	return rsv
+}
+
+// joinIDs join ids into the multi value parameter.
+func joinIDs(ids []int) string {
+	values := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+
+	return strings.Join(values, "|")
+}
+
+// missingIDs get requested ids that are not present in the result, in the requested order.
+func missingIDs(ids []int, pages map[int]PageData) []int {
+	missing := []int{}
+
+	for _, id := range ids {
+		if _, ok := pages[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
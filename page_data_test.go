@@ -94,6 +94,11 @@ const pageDataTestBdy = `{
 											"userid": %d,
 											"timestamp": "2021-01-03T19:49:57Z",
 											"comment": "Reverted 1 pending edit by [[Special:Contributions]] to revision 997918021",
+											"oresscores": {
+												"damaging": {"true": 0.04, "false": 0.96},
+												"goodfaith": {"true": 0.98, "false": 0.02},
+												"articlequality": {"FA": 0.1, "GA": 0.6, "B": 0.2, "C": 0.05, "Start": 0.03, "Stub": 0.02}
+											},
 											"slots": {
 												"main": {
 														"contentmodel": "wikitext",
@@ -130,7 +135,8 @@ const pageDataTestBdy = `{
 										"user": "Politicsfan4",
 										"userid": %d,
 										"timestamp": "2021-01-03T19:49:57Z",
-										"comment": "Reverted 1 pending edit by [[Special:Contributions]] to revision 997918021"
+										"comment": "Reverted 1 pending edit by [[Special:Contributions]] to revision 997918021",
+										"oresscores": []
 								}
 						]
 					},
@@ -182,7 +188,7 @@ func assertPage(assert *assert.Assertions, page PageData) {
 	assert.Equal(pageDataTestWatchers, page.Watchers)
 	assert.Equal(pageDataTestQID, page.Pageprops.WikibaseItem)
 	assert.Equal(pageDataTestRev, page.LastRevID)
-	assert.Equal(pageDataTestWikitext, page.Revisions[0].Slots.Main.Content)
+	assert.Equal(pageDataTestWikitext, page.Revisions[0].Slots.Main().Content)
 	assert.Equal(pageDataTestRedirectsPageID, page.Redirects[0].PageID)
 	assert.Equal(pageDataTestRedirectsNs, page.Redirects[0].Ns)
 	assert.Equal(pageDataTestRedirectsTitle, page.Redirects[0].Title)
@@ -195,6 +201,11 @@ func assertPage(assert *assert.Assertions, page PageData) {
 	assert.Equal(pageDataTestCategoriesNs, page.Categories[0].Ns)
 	assert.Equal(false, page.Categories[0].Hidden)
 	assert.Equal(pageDataStableRev, page.Flagged.StableRevID)
+	assert.Equal(0.04, page.Revisions[0].Oresscores.Damaging.True)
+	assert.Equal(0.98, page.Revisions[0].Oresscores.Goodfaith.True)
+	assert.Equal(0.6, page.Revisions[0].Oresscores.Articlequality.GA)
+	assert.Equal("GA", page.Revisions[0].Oresscores.Articlequality.Class())
+	assert.Empty(page.Revisions[0].Oresscores.Draftquality.Class())
 }
 
 func TestPageData(t *testing.T) {
@@ -210,12 +221,14 @@ func TestPageData(t *testing.T) {
 	assert.NoError(err)
 	assert.Contains(pages, pageDataTestTitle)
 	assert.NotContains(pages, pageDataTestMissingTitle)
-	assert.NotContains(pages, pageDataTestRedirectTitle)
+	assert.Contains(pages, pageDataTestRedirectTitle)
+	assertPage(assert, pages[pageDataTestTitle])
 
-	for title, page := range pages {
-		assert.Equal(pageDataTestTitle, title)
-		assertPage(assert, page)
-	}
+	redirect := pages[pageDataTestRedirectTitle]
+	assert.Equal("Redirect-1", redirect.Title)
+	assert.Equal(pageDataTestRedirectTitle, redirect.RedirectedFrom)
+	assert.Equal("Redirect-1", redirect.RedirectTarget)
+	assert.Equal([]string{pageDataTestRedirectTitle}, redirect.RedirectChain)
 
 	_, err = client.PageData(ctx, pageDataTestMissingTitle)
 	assert.Equal(ErrPageNotFound, err)
@@ -224,3 +237,311 @@ func TestPageData(t *testing.T) {
 	assert.NoError(err)
 	assertPage(assert, page)
 }
+
+const pageDataByIDsTestURL = "/page-data-by-ids"
+const pageDataByIDsTestPageID = 736
+const pageDataByIDsTestMissingPageID = 1
+const pageDataByIDsTestRevID = 1000
+const pageDataByIDsTestOtherRevID = 1001
+const pageDataByIDsTestBadRevID = 5
+
+func createPageDataByIDsServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(pageDataByIDsTestURL, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || len(r.Form.Get("redirects")) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := ""
+
+		switch {
+		case r.Form.Get("pageids") == fmt.Sprintf("%d|%d|-1", pageDataByIDsTestPageID, pageDataByIDsTestMissingPageID):
+			res = fmt.Sprintf(`{"query": {"pages": [
+				{"pageid": %d, "ns": 0, "title": "Albert Einstein", "revisions": [{"revid": %d}]},
+				{"pageid": %d, "missing": true}
+			]}}`, pageDataByIDsTestPageID, pageDataByIDsTestRevID, pageDataByIDsTestMissingPageID)
+		case r.Form.Get("revids") == fmt.Sprintf("%d|%d|%d", pageDataByIDsTestRevID, pageDataByIDsTestOtherRevID, pageDataByIDsTestBadRevID) && len(r.Form.Get("rvlimit")) == 0:
+			res = fmt.Sprintf(`{"query": {
+				"badrevids": {"%d": {"revid": %d, "missing": true}},
+				"pages": [{"pageid": %d, "ns": 0, "title": "Albert Einstein", "revisions": [
+					{"revid": %d, "comment": "first"},
+					{"revid": %d, "comment": "second"}
+				]}]
+			}}`, pageDataByIDsTestBadRevID, pageDataByIDsTestBadRevID, pageDataByIDsTestPageID, pageDataByIDsTestRevID, pageDataByIDsTestOtherRevID)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	return router
+}
+
+func TestPagesDataByIDs(t *testing.T) {
+	srv := httptest.NewServer(createPageDataByIDsServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = pageDataByIDsTestURL
+
+	pages, missing, err := client.PagesDataByIDs(context.Background(), []int{pageDataByIDsTestPageID, pageDataByIDsTestMissingPageID, -1})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 1)
+	assert.Equal(t, "Albert Einstein", pages[pageDataByIDsTestPageID].Title)
+	assert.Equal(t, []int{pageDataByIDsTestMissingPageID, -1}, missing)
+}
+
+func TestPagesDataByRevIDs(t *testing.T) {
+	srv := httptest.NewServer(createPageDataByIDsServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = pageDataByIDsTestURL
+
+	revids := []int{pageDataByIDsTestRevID, pageDataByIDsTestOtherRevID, pageDataByIDsTestBadRevID}
+	pages, missing, err := client.PagesDataByRevIDs(context.Background(), revids, PageDataOptions{RevisionsLimit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Equal(t, []int{pageDataByIDsTestBadRevID}, missing)
+
+	for _, revid := range revids[:2] {
+		assert.Equal(t, pageDataByIDsTestPageID, pages[revid].PageID)
+		assert.Len(t, pages[revid].Revisions, 1)
+		assert.Equal(t, revid, pages[revid].Revisions[0].RevID)
+	}
+
+	assert.Equal(t, "second", pages[pageDataByIDsTestOtherRevID].Revisions[0].Comment)
+}
+
+const pageDataPropsTestURL = "/page-data-props"
+
+func createPageDataPropsServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(pageDataPropsTestURL, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil ||
+			r.Form.Get("prop") != "info|revisions|links|langlinks|pageimages|description|contributors|linkshere" ||
+			r.Form.Get("rvprop") != "ids|timestamp|size" ||
+			r.Form.Get("llprop") != "url|langname|autonym" ||
+			len(r.Form.Get("cllimit")) > 0 ||
+			len(r.Form.Get("ppprop")) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.Form.Get("plcontinue") == "736|0|Relativity" {
+			_, _ = w.Write([]byte(`{"batchcomplete": true, "query": {"pages": [{
+				"pageid": 736,
+				"ns": 0,
+				"title": "Albert Einstein",
+				"links": [{"ns": 0, "title": "Relativity"}],
+				"contributors": [{"userid": 2, "name": "Other editor"}],
+				"anoncontributors": 3
+			}]}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"continue": {"plcontinue": "736|0|Relativity", "pccontinue": "736|2", "continue": "||info|revisions|langlinks|pageimages|description|linkshere"}, "query": {"pages": [{
+			"pageid": 736,
+			"ns": 0,
+			"title": "Albert Einstein",
+			"revisions": [{"revid": 1000, "timestamp": "2021-01-03T19:49:57Z"}],
+			"links": [{"ns": 0, "title": "Physics"}],
+			"langlinks": [{"lang": "de", "title": "Albert Einstein", "url": "https://de.wikipedia.org/wiki/Albert_Einstein", "langname": "German", "autonym": "Deutsch"}],
+			"thumbnail": {"source": "https://upload.wikimedia.org/thumb.jpg", "width": 50, "height": 66},
+			"pageimage": "Einstein_1921.jpg",
+			"description": "German-born theoretical physicist",
+			"descriptionsource": "local",
+			"contributors": [{"userid": 1, "name": "Editor"}],
+			"anoncontributors": 3,
+			"linkshere": [{"pageid": 2, "ns": 0, "title": "Einstein", "redirect": true}]
+		}]}}`))
+	})
+
+	return router
+}
+
+func TestPagesDataProps(t *testing.T) {
+	srv := httptest.NewServer(createPageDataPropsServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = pageDataPropsTestURL
+
+	page, err := client.PageData(context.Background(), "Albert Einstein", PageDataOptions{
+		Props: []string{
+			PageDataPropInfo,
+			PageDataPropRevisions,
+			PageDataPropLinks,
+			PageDataPropLangLinks,
+			PageDataPropPageImages,
+			PageDataPropDescription,
+			PageDataPropContributors,
+			PageDataPropLinksHere,
+		},
+		BaseRevisionProps: []string{"ids", "timestamp"},
+		RevisionProps:     []string{"size"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, page.Revisions[0].RevID)
+	assert.Len(t, page.Revisions, 1)
+	assert.Equal(t, "Physics", page.Links[0].Title)
+	assert.Equal(t, "Relativity", page.Links[1].Title)
+	assert.Len(t, page.LangLinks, 1)
+	assert.Equal(t, "de", page.LangLinks[0].Lang)
+	assert.Equal(t, "Deutsch", page.LangLinks[0].Autonym)
+	assert.Equal(t, 50, page.Thumbnail.Width)
+	assert.Nil(t, page.Original)
+	assert.Equal(t, "Einstein_1921.jpg", page.PageImage)
+	assert.Equal(t, "German-born theoretical physicist", page.Description)
+	assert.Equal(t, "Editor", page.Contributors[0].Name)
+	assert.Equal(t, "Other editor", page.Contributors[1].Name)
+	assert.Equal(t, 3, page.AnonContributors)
+	assert.True(t, page.LinksHere[0].Redirect)
+}
+
+const pageDataRedirectsTestURL = "/page-data-redirects"
+
+func createPageDataRedirectsServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(pageDataRedirectsTestURL, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := ""
+
+		switch {
+		case r.Form.Get("titles") == "einstein|Wikt link|E=mc2" && r.Form.Get("redirects") == "1":
+			res = `{"query": {
+				"normalized": [{"from": "einstein", "to": "Einstein"}],
+				"redirects": [
+					{"from": "Einstein", "to": "A. Einstein"},
+					{"from": "A. Einstein", "to": "Albert Einstein", "tofragment": "Early life"},
+					{"from": "Wikt link", "to": "relativity", "tointerwiki": "wikt"},
+					{"from": "E=mc2", "to": "Mass–energy equivalence"}
+				],
+				"interwiki": [{"title": "wikt:relativity", "iw": "wikt"}],
+				"pages": [
+					{"pageid": 736, "ns": 0, "title": "Albert Einstein"},
+					{"ns": 0, "title": "Mass–energy equivalence", "missing": true}
+				]
+			}}`
+		case r.Form.Get("titles") == "einstein" && len(r.Form.Get("redirects")) == 0:
+			res = `{"query": {
+				"normalized": [{"from": "einstein", "to": "Einstein"}],
+				"pages": [{"pageid": 100, "ns": 0, "title": "Einstein", "redirect": true}]
+			}}`
+		case r.Form.Get("titles") == "Einstein" && r.Form.Get("redirects") == "1" && r.Form.Get("prop") == PageDataPropInfo:
+			res = `{"query": {
+				"redirects": [
+					{"from": "Einstein", "to": "A. Einstein"},
+					{"from": "A. Einstein", "to": "Albert Einstein", "tofragment": "Early life"}
+				],
+				"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein"}]
+			}}`
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	return router
+}
+
+func TestPagesDataRedirects(t *testing.T) {
+	srv := httptest.NewServer(createPageDataRedirectsServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = pageDataRedirectsTestURL
+	ctx := context.Background()
+
+	t.Run("follow", func(t *testing.T) {
+		res, err := client.PagesDataDetailed(ctx, []string{"einstein", "Wikt link", "E=mc2"})
+		assert.NoError(t, err)
+		assert.Len(t, res.Pages, 1)
+		assert.Len(t, res.Missing, 1)
+
+		page := res.Pages["einstein"]
+		assert.Equal(t, 736, page.PageID)
+		assert.Equal(t, "Einstein", page.NormalizedTitle)
+		assert.Equal(t, "Einstein", page.RedirectedFrom)
+		assert.Equal(t, []string{"Einstein", "A. Einstein"}, page.RedirectChain)
+		assert.Equal(t, "Albert Einstein", page.RedirectTarget)
+		assert.Equal(t, "Early life", page.RedirectFragment)
+
+		iw := res.Interwiki["Wikt link"]
+		assert.Equal(t, "wikt", iw.Prefix)
+		assert.Equal(t, "wikt:relativity", iw.Title)
+		assert.Equal(t, []string{"Wikt link"}, iw.RedirectChain)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		pages, err := client.PagesData(ctx, []string{"einstein"}, PageDataOptions{ReportRedirects: true})
+		assert.NoError(t, err)
+
+		page := pages["einstein"]
+		assert.Equal(t, 100, page.PageID)
+		assert.True(t, page.Redirect)
+		assert.Equal(t, "Einstein", page.NormalizedTitle)
+		assert.Equal(t, []string{"Einstein", "A. Einstein"}, page.RedirectChain)
+		assert.Equal(t, "Albert Einstein", page.RedirectTarget)
+		assert.Equal(t, "Early life", page.RedirectFragment)
+	})
+}
+
+const pageDataDetailedTestURL = "/page-data-detailed"
+
+func createPageDataDetailedServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(pageDataDetailedTestURL, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"query": {
+			"normalized": [{"from": "talk:nowhere", "to": "Talk:Nowhere"}],
+			"interwiki": [{"title": "wikt:relativity", "iw": "wikt"}],
+			"pages": [
+				{"pageid": 736, "ns": 0, "title": "Albert Einstein"},
+				{"ns": 1, "title": "Talk:Nowhere", "missing": true},
+				{"title": "Bad[title]", "invalidreason": "The requested page title contains invalid characters: \"[\".", "invalid": true},
+				{"ns": -1, "title": "Special:Random", "special": true}
+			]
+		}}`))
+	})
+
+	return router
+}
+
+func TestPagesDataDetailed(t *testing.T) {
+	srv := httptest.NewServer(createPageDataDetailedServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageDataURL = pageDataDetailedTestURL
+
+	res, err := client.PagesDataDetailed(context.Background(), []string{"Albert Einstein", "talk:nowhere", "Bad[title]", "Special:Random", "wikt:relativity"})
+	assert.NoError(t, err)
+	assert.Len(t, res.Pages, 1)
+	assert.Equal(t, 736, res.Pages["Albert Einstein"].PageID)
+
+	assert.Len(t, res.Missing, 1)
+	assert.Equal(t, 1, res.Missing["talk:nowhere"].Ns)
+	assert.Equal(t, "Talk:Nowhere", res.Missing["talk:nowhere"].NormalizedTitle)
+
+	assert.Len(t, res.Invalid, 1)
+	assert.Contains(t, res.Invalid["Bad[title]"], "invalid characters")
+
+	assert.Len(t, res.Special, 1)
+	assert.Equal(t, -1, res.Special["Special:Random"].Ns)
+
+	assert.Len(t, res.Interwiki, 1)
+	assert.Equal(t, "wikt", res.Interwiki["wikt:relativity"].Prefix)
+}
@@ -0,0 +1,157 @@
+package mediawiki
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PageHistoryDefaultProps revision props requested when none are specified, content is left out.
+var PageHistoryDefaultProps = []string{"ids", "timestamp", "flags", "comment", "user", "userid", "size", "sha1", "tags"}
+
+// PageHistoryOptions optional parameters for PageHistory method.
+// Start and End limit the time window, with the default RevisionOrderingOlder Start is the newer end of the window.
+// StartID and EndID limit the window by revision ids the same way, User and ExcludeUser filter by the author.
+// Props default to PageHistoryDefaultProps, content needs "content" in Props and is returned for the Slots ("main" by default).
+// Limit is the number of revisions in a batch ("max" by default).
+type PageHistoryOptions struct {
+	Order       RevisionOrdering
+	Props       []string
+	Slots       []string
+	Start       time.Time
+	End         time.Time
+	StartID     int
+	EndID       int
+	User        string
+	ExcludeUser string
+	Tag         string
+	Limit       int
+}
+
+func (opts *PageHistoryOptions) params(title string) url.Values {
+	params := url.Values{
+		"action":        []string{"query"},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+		"prop":          []string{"revisions"},
+		"titles":        []string{title},
+		"rvlimit":       []string{"max"},
+		"rvprop":        []string{strings.Join(PageHistoryDefaultProps, "|")},
+		"rvslots":       []string{SlotMain},
+	}
+
+	if len(opts.Order) > 0 {
+		params.Set("rvdir", string(opts.Order))
+	}
+
+	if len(opts.Props) > 0 {
+		params.Set("rvprop", strings.Join(opts.Props, "|"))
+	}
+
+	if len(opts.Slots) > 0 {
+		params.Set("rvslots", strings.Join(opts.Slots, "|"))
+	}
+
+	if !opts.Start.IsZero() {
+		params.Set("rvstart", opts.Start.UTC().Format(time.RFC3339))
+	}
+
+	if !opts.End.IsZero() {
+		params.Set("rvend", opts.End.UTC().Format(time.RFC3339))
+	}
+
+	if opts.StartID > 0 {
+		params.Set("rvstartid", strconv.Itoa(opts.StartID))
+	}
+
+	if opts.EndID > 0 {
+		params.Set("rvendid", strconv.Itoa(opts.EndID))
+	}
+
+	if len(opts.User) > 0 {
+		params.Set("rvuser", opts.User)
+	}
+
+	if len(opts.ExcludeUser) > 0 {
+		params.Set("rvexcludeuser", opts.ExcludeUser)
+	}
+
+	if len(opts.Tag) > 0 {
+		params.Set("rvtag", opts.Tag)
+	}
+
+	if opts.Limit > 0 {
+		params.Set("rvlimit", strconv.Itoa(opts.Limit))
+	}
+
+	return params
+}
+
+// RevisionIterator revisions of the page history, batch by batch.
+//
+//	for history.Next() {
+//		fmt.Println(history.Batch())
+//	}
+//
+//	if err := history.Err(); err != nil {
+//		...
+//	}
+type RevisionIterator struct {
+	ctx    context.Context
+	client *Client
+	params url.Values
+	cont   map[string]string
+	done   bool
+	batch  []Revision
+	err    error
+}
+
+// Next fetch next batch of revisions, false is returned at the end of the history or on error.
+func (it *RevisionIterator) Next() bool {
+	it.batch = nil
+
+	for len(it.batch) == 0 && !it.done && it.err == nil {
+		it.batch, it.err = it.next()
+	}
+
+	return len(it.batch) > 0
+}
+
+// Batch get current batch of revisions.
+func (it *RevisionIterator) Batch() []Revision {
+	return it.batch
+}
+
+// Err get error that stopped the iteration, ErrPageNotFound if the page doesn't exist.
+func (it *RevisionIterator) Err() error {
+	return it.err
+}
+
+func (it *RevisionIterator) next() ([]Revision, error) {
+	params := url.Values{}
+
+	for key, value := range it.params {
+		params[key] = value
+	}
+
+	for key, value := range it.cont {
+		params.Set(key, value)
+	}
+
+	res, err := it.client.revisions(it.ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	it.cont = res.Continue
+	it.done = len(it.cont) == 0
+
+	if len(res.Query.Pages) == 0 || res.Query.Pages[0].Missing || res.Query.Pages[0].Invalid {
+		return nil, ErrPageNotFound
+	}
+
+	return res.Query.Pages[0].Revisions, nil
+}
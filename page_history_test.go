@@ -0,0 +1,96 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pageHistoryTestURL = "/history?"
+const pageHistoryTestTitle = "Albert Einstein"
+
+func createPageHistoryServer(t *testing.T) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc("/history", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		res := ""
+
+		switch query.Get("titles") {
+		case pageHistoryTestTitle:
+			assert.Equal(t, "2021-01-01T00:00:00Z", query.Get("rvstart"))
+			assert.Equal(t, "2020-01-01T00:00:00Z", query.Get("rvend"))
+			assert.Equal(t, "Bot", query.Get("rvexcludeuser"))
+			assert.Equal(t, "mw-reverted", query.Get("rvtag"))
+			assert.Equal(t, "ids|timestamp|size|sha1|tags|content", query.Get("rvprop"))
+			assert.Equal(t, "2", query.Get("rvlimit"))
+
+			switch query.Get("rvcontinue") {
+			case "":
+				res = `{
+					"continue": {"rvcontinue": "20200601000000|3", "continue": "||"},
+					"query": {"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein", "revisions": [
+						{"revid": 5, "parentid": 4, "timestamp": "2020-12-01T00:00:00Z", "size": 100, "sha1": "aaa", "tags": ["mw-reverted"], "slots": {"main": {"contentmodel": "wikitext", "content": "five"}}},
+						{"revid": 4, "parentid": 3, "timestamp": "2020-07-01T00:00:00Z", "size": 90, "sha1": "bbb", "tags": ["mw-reverted"], "slots": {"main": {"contentmodel": "wikitext", "content": "four"}}}
+					]}]}
+				}`
+			case "20200601000000|3":
+				res = `{
+					"batchcomplete": true,
+					"query": {"pages": [{"pageid": 736, "ns": 0, "title": "Albert Einstein", "revisions": [
+						{"revid": 3, "parentid": 0, "timestamp": "2020-06-01T00:00:00Z", "size": 80, "sha1": "ccc", "tags": ["mw-reverted"], "slots": {"main": {"contentmodel": "wikitext", "texthidden": true}}}
+					]}]}
+				}`
+			}
+		default:
+			res = `{"batchcomplete": true, "query": {"pages": [{"ns": 0, "title": "Nowhere", "missing": true}]}}`
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	return router
+}
+
+func TestPageHistory(t *testing.T) {
+	srv := httptest.NewServer(createPageHistoryServer(t))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageRevisionsURL = pageHistoryTestURL
+	ctx := context.Background()
+
+	history := client.PageHistory(ctx, pageHistoryTestTitle, PageHistoryOptions{
+		Props:       []string{"ids", "timestamp", "size", "sha1", "tags", "content"},
+		Start:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExcludeUser: "Bot",
+		Tag:         "mw-reverted",
+		Limit:       2,
+	})
+
+	revs := []Revision{}
+
+	for history.Next() {
+		revs = append(revs, history.Batch()...)
+	}
+
+	assert.NoError(t, history.Err())
+	assert.Len(t, revs, 3)
+	assert.Equal(t, 5, revs[0].RevID)
+	assert.Equal(t, 100, revs[0].Size)
+	assert.Equal(t, "aaa", revs[0].Sha1)
+	assert.Equal(t, []string{"mw-reverted"}, revs[0].Tags)
+	assert.Equal(t, "five", revs[0].Content())
+	assert.Equal(t, 3, revs[2].RevID)
+	assert.Empty(t, revs[2].Content())
+
+	history = client.PageHistory(ctx, "Nowhere")
+	assert.False(t, history.Next())
+	assert.True(t, errors.Is(history.Err(), ErrPageNotFound))
+}
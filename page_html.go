@@ -1,3 +1,78 @@
 package mediawiki
 
+import (
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
 const pageHTMLURL = "/api/rest_v1/page/html/"
+
+var pageHTMLRedirectRx = regexp.MustCompile(`<link\s+rel="mw:PageProp/redirect"\s+href="\./([^"#]*)(?:#[^"]*)?"`)
+
+// PageHTMLOptions additional optional parameters for PageHTMLWithMeta method.
+type PageHTMLOptions struct {
+	Revision       int
+	AcceptLanguage string
+	NoRedirect     bool
+	Stash          bool
+}
+
+// PageHTML page html together with response metadata.
+type PageHTML struct {
+	Content         []byte
+	ETag            string
+	Revision        int
+	TID             string
+	ContentLanguage string
+	ContentType     string
+	ProfileVersion  string
+	Redirect        bool
+	RedirectTarget  string
+}
+
+// parseETag extracts revision and time uuid from the REST API ETag header (W/"<rev>/<tid>").
+func parseETag(etag string) (int, string) {
+	value := strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+	parts := strings.SplitN(value, "/", 2)
+	rev, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return 0, ""
+	}
+
+	if len(parts) > 1 {
+		return rev, parts[1]
+	}
+
+	return rev, ""
+}
+
+// parseProfileVersion extracts HTML spec version from the Content-Type profile parameter.
+func parseProfileVersion(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return ""
+	}
+
+	profile := params["profile"]
+
+	if idx := strings.LastIndex(profile, "/"); idx != -1 {
+		return profile[idx+1:]
+	}
+
+	return profile
+}
+
+// parseRedirectTarget finds redirect target in html of a redirect page.
+func parseRedirectTarget(html []byte) (string, bool) {
+	match := pageHTMLRedirectRx.FindSubmatch(html)
+
+	if match == nil {
+		return "", false
+	}
+
+	return string(match[1]), true
+}
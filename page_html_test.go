@@ -2,6 +2,7 @@ package mediawiki
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +16,14 @@ const htmlTestURL = "/html"
 const htmlTestTitle = "test_html"
 const htmlTestRevision = 2
 const htmlTestBody = "<h1>Hello world</h1>"
+const htmlTestMetaTitle = "test_html_meta"
+const htmlTestRedirectTitle = "test_html_redirect"
+const htmlTestRedirectTarget = "Hello world"
+const htmlTestRedirectBody = `<link rel="mw:PageProp/redirect" href="./Hello_world"/>`
+const htmlTestTID = "5a8b2e70-1f1b-11eb-a1e7-5b0c1b0f8e3c"
+const htmlTestLanguage = "zh-hans"
+const htmlTestProfileVersion = "2.1.0"
+const htmlTestContentType = `text/html; charset=utf-8; profile="https://www.mediawiki.org/wiki/Specs/HTML/2.1.0"`
 
 func createHTMLServer() http.Handler {
 	router := http.NewServeMux()
@@ -37,6 +46,32 @@ func createHTMLServer() http.Handler {
 		}
 	})
 
+	router.HandleFunc(htmlTestURL+htmlTestMetaTitle+"/"+strconv.Itoa(htmlTestRevision), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", fmt.Sprintf(`W/"%d/%s"`, htmlTestRevision, htmlTestTID))
+		w.Header().Set("Content-Type", htmlTestContentType)
+		w.Header().Set("Content-Language", r.Header.Get("Accept-Language"))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(htmlTestBody))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	router.HandleFunc(htmlTestURL+htmlTestRedirectTitle, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("redirect") != "false" {
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(htmlTestRedirectBody))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
 	return router
 }
 
@@ -52,3 +87,32 @@ func TestPageHTML(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, htmlTestBody, string(html))
 }
+
+func TestPageHTMLWithMeta(t *testing.T) {
+	srv := httptest.NewServer(createHTMLServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageHTMLURL = htmlTestURL
+
+	html, err := client.PageHTMLWithMeta(context.Background(), htmlTestMetaTitle, PageHTMLOptions{
+		Revision:       htmlTestRevision,
+		AcceptLanguage: htmlTestLanguage,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, htmlTestBody, string(html.Content))
+	assert.Equal(t, htmlTestRevision, html.Revision)
+	assert.Equal(t, htmlTestTID, html.TID)
+	assert.Equal(t, htmlTestLanguage, html.ContentLanguage)
+	assert.Equal(t, htmlTestProfileVersion, html.ProfileVersion)
+	assert.False(t, html.Redirect)
+
+	html, err = client.PageHTMLWithMeta(context.Background(), htmlTestRedirectTitle, PageHTMLOptions{
+		NoRedirect: true,
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, html.Redirect)
+	assert.Equal(t, htmlTestRedirectTarget, html.RedirectTarget)
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,4 +43,9 @@ func TestPageMeta(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, metaTestTitle, meta.Title)
 	assert.Equal(t, metaTestRevision, meta.Rev)
+
+	meta, err = client.PageMeta(context.Background(), strings.ReplaceAll(metaTestTitle, "_", " "))
+
+	assert.Nil(t, err)
+	assert.Equal(t, metaTestRevision, meta.Rev)
 }
@@ -5,20 +5,40 @@ import "time"
 const revisionsURL = "/w/api.php?"
 
 // PageRevisionsOptions additional optional parameters for PageRevisions method
+// Slots are the slot roles to return with the revisions ("main", "mediainfo" or "*"), content needs "content" in Props.
 type PageRevisionsOptions struct {
 	Order RevisionOrdering
 	Props []string
+	Slots []string
 }
 
-// Revision page revision schema
+// Revision page revision schema, fields are filled according to the requested props
 type Revision struct {
-	RevID     int       `json:"revid"`
-	ParentID  int       `json:"parentid"`
-	Minor     bool      `json:"minor"`
-	User      string    `json:"user"`
-	Timestamp time.Time `json:"timestamp"`
-	Comment   string    `json:"comment"`
-	Anon      bool      `json:"anon,omitempty"`
+	RevID         int                   `json:"revid"`
+	ParentID      int                   `json:"parentid"`
+	Minor         bool                  `json:"minor"`
+	User          string                `json:"user"`
+	UserID        int                   `json:"userid,omitempty"`
+	Timestamp     time.Time             `json:"timestamp"`
+	Comment       string                `json:"comment"`
+	ParsedComment string                `json:"parsedcomment,omitempty"`
+	Anon          bool                  `json:"anon,omitempty"`
+	Size          int                   `json:"size,omitempty"`
+	Sha1          string                `json:"sha1,omitempty"`
+	Tags          []string              `json:"tags,omitempty"`
+	ContentModel  string                `json:"contentmodel,omitempty"`
+	Roles         []string              `json:"roles,omitempty"`
+	UserHidden    bool                  `json:"userhidden,omitempty"`
+	CommentHidden bool                  `json:"commenthidden,omitempty"`
+	TextHidden    bool                  `json:"texthidden,omitempty"`
+	Sha1Hidden    bool                  `json:"sha1hidden,omitempty"`
+	Suppressed    bool                  `json:"suppressed,omitempty"`
+	Slots         PageDataRevisionSlots `json:"slots,omitempty"`
+}
+
+// Content get content of the main slot, content has to be requested.
+func (rev *Revision) Content() string {
+	return rev.Slots.Main().Content
 }
 
 // RevisionOrdering specifies the direction to enumerate the revisions list
@@ -33,6 +53,7 @@ const (
 
 type revisionsResponse struct {
 	Batchcomplete bool                         `json:"batchcomplete"`
+	Continue      map[string]string            `json:"continue"`
 	Warnings      map[string]map[string]string `json:"warnings"`
 	Query         struct {
 		Normalized []struct {
@@ -44,6 +65,8 @@ type revisionsResponse struct {
 			PageID    int        `json:"pageid"`
 			Ns        int        `json:"ns"`
 			Title     string     `json:"title"`
+			Missing   bool       `json:"missing"`
+			Invalid   bool       `json:"invalid"`
 			Revisions []Revision `json:"revisions"`
 		} `json:"pages"`
 	} `json:"query"`
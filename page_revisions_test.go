@@ -32,9 +32,10 @@ func createPageRevisionsServer(t *testing.T) http.Handler {
 		assert.True(t, values.Has("rvlimit") && assert.Equal(t, strconv.Itoa(pageRevisionsTestLimit), values.Get("rvlimit")))
 		assert.True(t, values.Has("rvdir") && assert.Equal(t, string(pageRevisionsTestOrder), values.Get("rvdir")))
 		assert.True(t, values.Has("rvprop") && assert.Equal(t, pageRevisionsTestProps, values.Get("rvprop")))
+		assert.True(t, values.Has("rvslots") && assert.Equal(t, SlotAll, values.Get("rvslots")))
 
 		w.WriteHeader(http.StatusOK)
-		_, err = w.Write([]byte(fmt.Sprintf(`{"continue":{"rvcontinue":"20200310174438|944912225","continue":"||"},"query":{"normalized":[{"fromencoded":false,"from":"Pet_door","to":"Pet door"}],"pages":[{"pageid":3276454,"ns":0,"title":"%s","revisions":[{"revid":%d,"parentid":944912305,"minor":true,"user":"IdreamofJeanie","timestamp":"2020-03-10T18:17:36Z","comment":"Reverted 2 edits by [[Special:Contributions\/112.196.52.43|112.196.52.43]] ([[User talk:112.196.52.43|talk]]) to last revision by LizzieBabes419 ([[WP:TW|TW]])"},{"revid":%d,"parentid":944912225,"minor":false,"user":"112.196.52.43","anon":true,"timestamp":"2020-03-10T17:45:04Z","comment":"\/* References *\/"}]}]}}`, pageRevisionsTestTitle, pageRevisionsTestIDS[0], pageRevisionsTestIDS[1])))
+		_, err = w.Write([]byte(fmt.Sprintf(`{"continue":{"rvcontinue":"20200310174438|944912225","continue":"||"},"query":{"normalized":[{"fromencoded":false,"from":"Pet_door","to":"Pet door"}],"pages":[{"pageid":3276454,"ns":0,"title":"%s","revisions":[{"revid":%d,"parentid":944912305,"minor":true,"user":"IdreamofJeanie","timestamp":"2020-03-10T18:17:36Z","slots":{"main":{"contentmodel":"wikitext","contentformat":"text/x-wiki","content":"text"},"mediainfo":{"contentmodel":"wikibase-mediainfo","contentformat":"application/json","content":"{}"}},"comment":"Reverted 2 edits by [[Special:Contributions\/112.196.52.43|112.196.52.43]] ([[User talk:112.196.52.43|talk]]) to last revision by LizzieBabes419 ([[WP:TW|TW]])"},{"revid":%d,"parentid":944912225,"minor":false,"user":"112.196.52.43","anon":true,"timestamp":"2020-03-10T17:45:04Z","comment":"\/* References *\/"}]}]}}`, pageRevisionsTestTitle, pageRevisionsTestIDS[0], pageRevisionsTestIDS[1])))
 
 		assert.NoError(t, err)
 	})
@@ -55,6 +56,7 @@ func TestPageRevisions(t *testing.T) {
 		PageRevisionsOptions{
 			Order: pageRevisionsTestOrder,
 			Props: []string{"content", "ids"},
+			Slots: []string{SlotAll},
 		},
 	)
 
@@ -72,4 +74,7 @@ func TestPageRevisions(t *testing.T) {
 			assert.True(t, ok)
 		}
 	}
+
+	assert.Equal(t, "text", lookup[pageRevisionsTestIDS[0]].Slots.Main().Content)
+	assert.Contains(t, lookup[pageRevisionsTestIDS[0]].Slots, SlotMediaInfo)
 }
@@ -13,13 +13,7 @@ type wikitextResponse struct {
 			Ns        int    `json:"ns"`
 			Title     string `json:"title"`
 			Revisions []struct {
-				Slots struct {
-					Main struct {
-						Contentmodel  string `json:"contentmodel"`
-						Contentformat string `json:"contentformat"`
-						Content       string `json:"content"`
-					} `json:"main"`
-				} `json:"slots"`
+				Slots PageDataRevisionSlots `json:"slots"`
 			} `json:"revisions"`
 		} `json:"pages"`
 	} `json:"query"`
@@ -15,6 +15,7 @@ const pageWikitextTestURL = "/wikitext"
 const pageWikitextTestTitle = "test"
 const pageWikitextTestRevision = 1
 const pageWikitextTestContent = "hello world"
+const pageSlotsTestTitle = "File:Test.jpg"
 
 func createPageWikitextServer() http.Handler {
 	router := http.NewServeMux()
@@ -28,6 +29,36 @@ func createPageWikitextServer() http.Handler {
 		}
 	})
 
+	router.HandleFunc(pageWikitextTestURL+"/"+pageSlotsTestTitle, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("test") != "1" || len(query["rvslots"]) != 1 || len(query["rvprop"]) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if query.Get("rvslots") == SlotMediaInfo {
+			_, _ = w.Write([]byte(`{"query": {"pages": [{"pageid": 1, "ns": 6, "revisions": [{"slots": {
+				"mediainfo": {"contentmodel": "wikibase-mediainfo", "contentformat": "application/json", "content": "{}"}
+			}}]}]}}`))
+			return
+		}
+
+		if query.Get("rvslots") != SlotAll || query.Get("rvprop") != "content|size|sha1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, err := w.Write([]byte(fmt.Sprintf(`{"query": {"pages": [{"pageid": 1, "ns": 6, "title": "%s", "revisions": [{"slots": {
+			"main": {"size": 11, "sha1": "abc", "contentmodel": "wikitext", "contentformat": "text/x-wiki", "content": "%s"},
+			"mediainfo": {"size": 64, "sha1": "def", "contentmodel": "wikibase-mediainfo", "contentformat": "application/json", "content": "{\"type\":\"mediainfo\",\"id\":\"M1\",\"labels\":[]}"}
+		}}]}]}}`, pageSlotsTestTitle, pageWikitextTestContent)))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
 	return router
 }
 
@@ -43,3 +74,40 @@ func TestPageWikitext(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, pageWikitextTestContent, string(wikitext))
 }
+
+func TestPageSlots(t *testing.T) {
+	srv := httptest.NewServer(createPageWikitextServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageWikitextURL = pageWikitextTestURL + "/%s?test=1"
+
+	slots, err := client.PageSlots(context.Background(), pageSlotsTestTitle, nil)
+	assert.NoError(t, err)
+	assert.Len(t, slots, 2)
+	assert.Equal(t, pageWikitextTestContent, slots.Main().Content)
+	assert.Equal(t, 11, slots.Main().Size)
+	assert.Equal(t, "def", slots[SlotMediaInfo].Sha1)
+
+	mediaInfo := slots[SlotMediaInfo]
+	info, err := mediaInfo.MediaInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "M1", info.ID)
+	assert.Empty(t, info.Labels)
+}
+
+func TestPageWikitextSlot(t *testing.T) {
+	srv := httptest.NewServer(createPageWikitextServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.PageWikitextURL = pageWikitextTestURL + "/%s?test=1&rvprop=content&rvslots=main"
+
+	content, err := client.PageWikitextSlot(context.Background(), pageSlotsTestTitle, SlotMediaInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(content))
+
+	slots, err := client.PageSlots(context.Background(), pageSlotsTestTitle, nil)
+	assert.NoError(t, err)
+	assert.Len(t, slots, 2)
+}
@@ -0,0 +1,32 @@
+package mediawiki
+
+import "sync"
+
+// parallel call fn for every index from 0 to n, at most limit calls run at once.
+// All the calls are made, the error of the first failed index is returned.
+func parallel(n int, limit int, fn func(idx int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	wg := new(sync.WaitGroup)
+
+	for idx := 0; idx < n; idx++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[idx] = fn(idx)
+		}(idx)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
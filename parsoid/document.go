@@ -0,0 +1,219 @@
+// Package parsoid parses Parsoid HTML returned by REST API into navigable document model.
+package parsoid
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Document parsed Parsoid HTML document.
+type Document struct {
+	Root          *html.Node
+	Title         string
+	Revision      int
+	Sections      []*Section
+	Links         []Link
+	ExternalLinks []ExternalLink
+	Templates     []Template
+	Media         []Media
+	AboutGroups   []*AboutGroup
+	about         map[string]*AboutGroup
+}
+
+// AboutGroup nodes sharing the same "about" attribute, generated by single transclusion or extension.
+type AboutGroup struct {
+	ID     string
+	Typeof []string
+	Nodes  []*html.Node
+}
+
+// Parse parse Parsoid HTML into the document.
+func Parse(data []byte) (*Document, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parse Parsoid HTML from reader into the document.
+func ParseReader(r io.Reader) (*Document, error) {
+	root, err := html.Parse(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		Root:  root,
+		about: map[string]*AboutGroup{},
+	}
+
+	doc.walk(root, nil)
+	return doc, nil
+}
+
+// AboutGroup get about group by it's id (for example "#mwt3").
+func (doc *Document) AboutGroup(id string) *AboutGroup {
+	return doc.about[id]
+}
+
+// Body get document body node.
+func (doc *Document) Body() *html.Node {
+	return Find(doc.Root, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "body"
+	})
+}
+
+func (doc *Document) walk(n *html.Node, section *Section) {
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "html":
+			doc.Revision = parseRevision(Attr(n, "about"))
+		case "title":
+			doc.Title = Text(n)
+		case "section":
+			if id, err := strconv.Atoi(Attr(n, "data-mw-section-id")); err == nil {
+				section = doc.addSection(n, id, section)
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if section != nil && section.HeadingNode == nil && n.Parent == section.Node {
+				section.setHeading(n)
+			}
+		case "a":
+			if HasToken(Attr(n, "rel"), "mw:WikiLink") {
+				doc.Links = append(doc.Links, newLink(n))
+			} else if HasToken(Attr(n, "rel"), "mw:ExtLink") {
+				doc.ExternalLinks = append(doc.ExternalLinks, newExternalLink(n))
+			}
+		}
+
+		if id := Attr(n, "about"); strings.HasPrefix(id, "#") {
+			doc.addToAboutGroup(n, id)
+		}
+
+		if isMedia(n) {
+			doc.Media = append(doc.Media, newMedia(n))
+		}
+
+		if HasToken(Attr(n, "typeof"), "mw:Transclusion") {
+			doc.Templates = append(doc.Templates, newTemplates(n)...)
+		}
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		doc.walk(child, section)
+	}
+}
+
+func (doc *Document) addToAboutGroup(n *html.Node, id string) {
+	group, ok := doc.about[id]
+
+	if !ok {
+		group = &AboutGroup{ID: id}
+		doc.about[id] = group
+		doc.AboutGroups = append(doc.AboutGroups, group)
+	}
+
+	if typeof := Attr(n, "typeof"); len(typeof) > 0 && len(group.Typeof) == 0 {
+		group.Typeof = strings.Fields(typeof)
+	}
+
+	group.Nodes = append(group.Nodes, n)
+}
+
+func parseRevision(about string) int {
+	idx := strings.LastIndex(about, "/revision/")
+
+	if idx == -1 {
+		return 0
+	}
+
+	rev, _ := strconv.Atoi(about[idx+len("/revision/"):])
+	return rev
+}
+
+// Attr get attribute value of the node, empty string if not present.
+func Attr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+// HasToken check if space separated attribute value (like "rel", "typeof" or "class") contains token.
+func HasToken(value string, token string) bool {
+	for _, field := range strings.Fields(value) {
+		if field == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasTokenPrefix check if space separated attribute value contains token with the prefix.
+func HasTokenPrefix(value string, prefix string) bool {
+	for _, field := range strings.Fields(value) {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Text get text content of the node with all the descendants.
+func Text(n *html.Node) string {
+	buf := new(strings.Builder)
+	text(n, buf)
+	return buf.String()
+}
+
+func text(n *html.Node, buf *strings.Builder) {
+	if n.Type == html.TextNode {
+		buf.WriteString(n.Data)
+		return
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		text(child, buf)
+	}
+}
+
+// Find find first node (including root itself) matching the predicate in depth first order.
+func Find(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if match(n) {
+		return n
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := Find(child, match); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// FindAll find all nodes (including root itself) matching the predicate in depth first order.
+func FindAll(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	nodes := []*html.Node{}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if match(n) {
+			nodes = append(nodes, n)
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+
+	walk(n)
+	return nodes
+}
@@ -0,0 +1,76 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const documentTestTitle = "Ninja"
+const documentTestRevision = 998092778
+const documentTestHTML = `<!DOCTYPE html>
+<html prefix="dc: http://purl.org/dc/terms/ mw: http://mediawiki.org/rdf/" about="https://en.wikipedia.org/wiki/Special:Redirect/revision/998092778">
+<head><title>Ninja</title></head>
+<body lang="en" class="mw-content-ltr sitedir-ltr ltr mw-body-content parsoid-body mediawiki mw-parser-output" dir="ltr">
+<section data-mw-section-id="0" id="mwAQ">
+<table class="infobox vcard" about="#mwt1" typeof="mw:Transclusion" data-mw='{"parts":[{"template":{"target":{"wt":"Infobox person\n","href":"./Template:Infobox_person"},"params":{"name":{"wt":"Hattori Hanzō"},"birth_date":{"wt":"1542"},"1":{"wt":"positional"}},"i":0}}]}' id="mwAg">
+<tbody>
+<tr><th colspan="2" class="infobox-above">Hattori Hanzō</th></tr>
+<tr><th scope="row" class="infobox-label">Born</th><td class="infobox-data">1542<br/><a rel="mw:WikiLink" href="./Mikawa_Province" title="Mikawa Province">Mikawa Province</a></td></tr>
+<tr><th scope="row" class="infobox-label">Occupation</th><td class="infobox-data">Samurai</td></tr>
+</tbody>
+</table>
+<link rel="mw:PageProp/Category" href="./Category:Japan" about="#mwt1"/>
+<p id="mwAw">A <b>ninja</b> was a covert agent in <a rel="mw:WikiLink" href="./Feudal_Japan#Sengoku" title="Feudal Japan">feudal Japan</a>.<sup about="#mwt2" class="mw-ref reference" id="cite_ref-1" rel="dc:references" typeof="mw:Extension/ref" data-mw='{"name":"ref","attrs":{"name":"turnbull"},"body":{"id":"mw-reference-text-cite_note-1"}}'><a href="./Ninja#cite_note-1" style="counter-reset: mw-Ref 1;"><span class="mw-reflink-text">[1]</span></a></sup> See also <a rel="mw:WikiLink" href="./Shinobi?action=edit&amp;redlink=1" title="Shinobi" class="new">shinobi</a>.</p>
+<figure class="mw-default-size" typeof="mw:File/Thumb" id="mwBA"><a href="./File:Ninja.jpg" class="mw-file-description"><img resource="./File:Ninja.jpg" src="//upload.wikimedia.org/wikipedia/commons/thumb/a/a0/Ninja.jpg/220px-Ninja.jpg" decoding="async" data-file-width="800" data-file-height="1200" data-file-type="bitmap" height="330" width="220" alt="A ninja"/></a><figcaption>A <a rel="mw:WikiLink" href="./Ninja" title="Ninja">ninja</a> in costume</figcaption></figure>
+</section>
+<section data-mw-section-id="1" id="mwBQ"><h2 id="History">History</h2>
+<p id="mwBg">Text with <a rel="mw:ExtLink" href="https://example.org/ninja" class="external text">external source</a>.<sup about="#mwt3" class="mw-ref reference" id="cite_ref-2" rel="dc:references" typeof="mw:Extension/ref" data-mw='{"name":"ref","body":{"id":"mw-reference-text-cite_note-2"}}'><a href="./Ninja#cite_note-2" style="counter-reset: mw-Ref 2;"><span class="mw-reflink-text">[2]</span></a></sup></p>
+<section data-mw-section-id="2" id="mwBw"><h3 id="Origins">Origins</h3>
+<p id="mwCA">Second <sup about="#mwt2" class="mw-ref reference" id="cite_ref-turnbull_1-1" rel="dc:references" typeof="mw:Extension/ref" data-mw='{"name":"ref","attrs":{"name":"turnbull"}}'><a href="./Ninja#cite_note-1" style="counter-reset: mw-Ref 1;"><span class="mw-reflink-text">[1]</span></a></sup> paragraph.</p>
+<table class="wikitable" id="mwCQ">
+<tbody>
+<tr><th>Clan</th><th>Province</th><th>Members</th></tr>
+<tr><td rowspan="2">Iga</td><td>Iga</td><td>100</td></tr>
+<tr><td colspan="2">Unknown</td></tr>
+</tbody>
+</table>
+</section>
+</section>
+<section data-mw-section-id="3" id="mwCg"><h2 id="References">References</h2>
+<div class="mw-references-wrap" typeof="mw:Extension/references" about="#mwt4" data-mw='{"name":"references","attrs":{}}'><ol class="mw-references references"><li about="#cite_note-1" id="cite_note-1"><span class="mw-cite-backlink"><a href="./Ninja#cite_ref-turnbull_1-0" rel="mw:referencedBy"><span class="mw-linkback-text">↑ </span></a></span> <span id="mw-reference-text-cite_note-1" class="mw-reference-text"><span about="#mwt5" typeof="mw:Transclusion" data-mw='{"parts":[{"template":{"target":{"wt":"cite book","href":"./Template:Cite_book"},"params":{"title":{"wt":"Ninja AD 1460–1650"},"author":{"wt":"Stephen Turnbull"},"date":{"wt":"2003"},"isbn":{"wt":"978-1-84176-525-9"},"url":{"wt":"https://example.org/book"}},"i":0}}]}'>Turnbull, Stephen (2003). <a rel="mw:ExtLink" href="https://example.org/book" class="external text">Ninja AD 1460–1650</a>.</span></span></li><li about="#cite_note-2" id="cite_note-2"><span class="mw-cite-backlink" rel="mw:referencedBy"><a href="./Ninja#cite_ref-2"><span class="mw-linkback-text">↑ </span></a></span> <span id="mw-reference-text-cite_note-2" class="mw-reference-text">Plain note <a rel="mw:ExtLink" href="https://example.org/note" class="external free">https://example.org/note</a></span></li></ol></div>
+</section>
+</body>
+</html>`
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+
+	assert.NoError(err)
+	assert.Equal(documentTestTitle, doc.Title)
+	assert.Equal(documentTestRevision, doc.Revision)
+	assert.NotNil(doc.Body())
+	assert.Len(doc.Sections, 3)
+	assert.Len(doc.ExternalLinks, 3)
+	assert.Equal("https://example.org/ninja", doc.ExternalLinks[0].URL)
+	assert.Equal("external source", doc.ExternalLinks[0].Text)
+}
+
+func TestAboutGroups(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	group := doc.AboutGroup("#mwt1")
+	assert.NotNil(group)
+	assert.Equal([]string{"mw:Transclusion"}, group.Typeof)
+	assert.Len(group.Nodes, 2)
+	assert.Equal("table", group.Nodes[0].Data)
+	assert.Equal("link", group.Nodes[1].Data)
+
+	group = doc.AboutGroup("#mwt2")
+	assert.NotNil(group)
+	assert.Len(group.Nodes, 2)
+	assert.Nil(doc.AboutGroup("#mwt100"))
+}
@@ -0,0 +1,89 @@
+package parsoid
+
+import (
+	"golang.org/x/net/html"
+)
+
+// InfoboxField single infobox row as key and value with link targets kept.
+type InfoboxField struct {
+	Key   string
+	Value string
+	Links []Link
+}
+
+// Infobox infobox table as ordered list of fields.
+type Infobox struct {
+	Title    string
+	Template string
+	Fields   []InfoboxField
+	Node     *html.Node
+}
+
+// Field get infobox field by key.
+func (ib *Infobox) Field(key string) (InfoboxField, bool) {
+	for _, field := range ib.Fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return InfoboxField{}, false
+}
+
+// Infoboxes get all infoboxes (tables with "infobox" class) of the document.
+func (doc *Document) Infoboxes() []Infobox {
+	infoboxes := []Infobox{}
+	nodes := FindAll(doc.Root, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "table" && HasTokenPrefix(Attr(n, "class"), "infobox")
+	})
+
+	for _, n := range nodes {
+		infoboxes = append(infoboxes, doc.newInfobox(n))
+	}
+
+	return infoboxes
+}
+
+func (doc *Document) newInfobox(n *html.Node) Infobox {
+	ib := Infobox{Node: n}
+
+	for _, tpl := range doc.Templates {
+		if tpl.Node == n || (len(tpl.About) > 0 && tpl.About == Attr(n, "about")) {
+			ib.Template = tpl.Name
+			break
+		}
+	}
+
+	if caption := Find(n, isElement("caption")); caption != nil {
+		ib.Title = CleanText(caption)
+	}
+
+	for _, tr := range tableRows(n) {
+		var key, value *html.Node
+
+		for cell := tr.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.Type != html.ElementNode {
+				continue
+			}
+
+			if cell.Data == "th" && key == nil && value == nil {
+				key = cell
+			} else if cell.Data == "td" && value == nil {
+				value = cell
+			}
+		}
+
+		switch {
+		case key != nil && value != nil:
+			ib.Fields = append(ib.Fields, InfoboxField{
+				Key:   CleanText(key),
+				Value: CleanText(value),
+				Links: links(value),
+			})
+		case key != nil && len(ib.Title) == 0 && len(ib.Fields) == 0:
+			ib.Title = CleanText(key)
+		}
+	}
+
+	return ib
+}
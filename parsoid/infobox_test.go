@@ -0,0 +1,31 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfoboxes(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	infoboxes := doc.Infoboxes()
+	assert.Len(infoboxes, 1)
+
+	infobox := infoboxes[0]
+	assert.Equal("Hattori Hanzō", infobox.Title)
+	assert.Equal("Infobox person", infobox.Template)
+	assert.Len(infobox.Fields, 2)
+	assert.Equal("Born", infobox.Fields[0].Key)
+	assert.Equal("1542\nMikawa Province", infobox.Fields[0].Value)
+	assert.Equal("Mikawa Province", infobox.Fields[0].Links[0].Title)
+
+	field, ok := infobox.Field("Occupation")
+	assert.True(ok)
+	assert.Equal("Samurai", field.Value)
+
+	_, ok = infobox.Field("Died")
+	assert.False(ok)
+}
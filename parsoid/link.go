@@ -0,0 +1,72 @@
+package parsoid
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Link wikilink (rel="mw:WikiLink") resolved to page title.
+type Link struct {
+	Title    string
+	Fragment string
+	Href     string
+	Text     string
+	Redlink  bool
+	Node     *html.Node
+}
+
+// ExternalLink external link (rel="mw:ExtLink").
+type ExternalLink struct {
+	URL  string
+	Text string
+	Node *html.Node
+}
+
+// TitleFromHref resolve Parsoid relative href ("./Foo_bar#Baz") to page title and fragment.
+func TitleFromHref(href string) (string, string) {
+	href = strings.TrimPrefix(href, "./")
+
+	if idx := strings.Index(href, "?"); idx != -1 {
+		href = href[:idx]
+	}
+
+	fragment := ""
+
+	if idx := strings.Index(href, "#"); idx != -1 {
+		href, fragment = href[:idx], href[idx+1:]
+	}
+
+	if title, err := url.PathUnescape(href); err == nil {
+		href = title
+	}
+
+	if frag, err := url.PathUnescape(fragment); err == nil {
+		fragment = frag
+	}
+
+	return strings.ReplaceAll(href, "_", " "), strings.ReplaceAll(fragment, "_", " ")
+}
+
+func newLink(n *html.Node) Link {
+	href := Attr(n, "href")
+	title, fragment := TitleFromHref(href)
+
+	return Link{
+		Title:    title,
+		Fragment: fragment,
+		Href:     href,
+		Text:     Text(n),
+		Redlink:  HasToken(Attr(n, "class"), "new") || strings.Contains(href, "redlink=1"),
+		Node:     n,
+	}
+}
+
+func newExternalLink(n *html.Node) ExternalLink {
+	return ExternalLink{
+		URL:  Attr(n, "href"),
+		Text: Text(n),
+		Node: n,
+	}
+}
@@ -0,0 +1,34 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinks(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	assert.Len(doc.Links, 4)
+	assert.Equal("Mikawa Province", doc.Links[0].Title)
+	assert.Equal("Feudal Japan", doc.Links[1].Title)
+	assert.Equal("Sengoku", doc.Links[1].Fragment)
+	assert.Equal("feudal Japan", doc.Links[1].Text)
+	assert.False(doc.Links[1].Redlink)
+	assert.Equal("Shinobi", doc.Links[2].Title)
+	assert.True(doc.Links[2].Redlink)
+}
+
+func TestTitleFromHref(t *testing.T) {
+	assert := assert.New(t)
+
+	title, fragment := TitleFromHref("./Caf%C3%A9_au_lait#Milk_ratio")
+	assert.Equal("Café au lait", title)
+	assert.Equal("Milk ratio", fragment)
+
+	title, fragment = TitleFromHref("./Talk:Foo?action=edit&redlink=1")
+	assert.Equal("Talk:Foo", title)
+	assert.Empty(fragment)
+}
@@ -0,0 +1,77 @@
+package parsoid
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Media media file embedded into the page (images, videos and audio).
+type Media struct {
+	Type       string
+	Format     string
+	Resource   string
+	Src        string
+	Alt        string
+	Caption    string
+	Width      int
+	Height     int
+	FileWidth  int
+	FileHeight int
+	FileType   string
+	Node       *html.Node
+}
+
+var mediaTypes = []string{"mw:File", "mw:Image", "mw:Video", "mw:Audio"}
+
+func mediaTypeof(n *html.Node) string {
+	for _, field := range strings.Fields(Attr(n, "typeof")) {
+		for _, prefix := range mediaTypes {
+			if field == prefix || strings.HasPrefix(field, prefix+"/") {
+				return field
+			}
+		}
+	}
+
+	return ""
+}
+
+func isMedia(n *html.Node) bool {
+	return len(mediaTypeof(n)) > 0
+}
+
+func newMedia(n *html.Node) Media {
+	media := Media{Node: n}
+	parts := strings.SplitN(mediaTypeof(n), "/", 2)
+	media.Type = strings.TrimPrefix(parts[0], "mw:")
+
+	if len(parts) > 1 {
+		media.Format = parts[1]
+	}
+
+	elem := Find(n, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && (n.Data == "img" || n.Data == "video" || n.Data == "audio")
+	})
+
+	if elem != nil {
+		media.Resource, _ = TitleFromHref(Attr(elem, "resource"))
+		media.Src = Attr(elem, "src")
+		media.Alt = Attr(elem, "alt")
+		media.Width, _ = strconv.Atoi(Attr(elem, "width"))
+		media.Height, _ = strconv.Atoi(Attr(elem, "height"))
+		media.FileWidth, _ = strconv.Atoi(Attr(elem, "data-file-width"))
+		media.FileHeight, _ = strconv.Atoi(Attr(elem, "data-file-height"))
+		media.FileType = Attr(elem, "data-file-type")
+	}
+
+	caption := Find(n, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "figcaption"
+	})
+
+	if caption != nil {
+		media.Caption = strings.TrimSpace(Text(caption))
+	}
+
+	return media
+}
@@ -0,0 +1,27 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedia(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	assert.Len(doc.Media, 1)
+
+	media := doc.Media[0]
+	assert.Equal("File", media.Type)
+	assert.Equal("Thumb", media.Format)
+	assert.Equal("File:Ninja.jpg", media.Resource)
+	assert.Equal("A ninja", media.Alt)
+	assert.Equal("A ninja in costume", media.Caption)
+	assert.Equal(220, media.Width)
+	assert.Equal(330, media.Height)
+	assert.Equal(800, media.FileWidth)
+	assert.Equal(1200, media.FileHeight)
+	assert.Equal("bitmap", media.FileType)
+}
@@ -0,0 +1,242 @@
+package parsoid
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Citation citation template found in the reference text.
+type Citation struct {
+	Template   string
+	Params     Params
+	Title      string
+	Author     string
+	Date       string
+	URL        string
+	DOI        string
+	ISBN       string
+	ArchiveURL string
+}
+
+// Reference footnote from the references list together with the paragraphs that cite it.
+type Reference struct {
+	ID         string
+	Number     int
+	Group      string
+	Name       string
+	Text       string
+	URLs       []string
+	Citations  []Citation
+	Paragraphs []*html.Node
+	Markers    []*html.Node
+	Node       *html.Node
+}
+
+// ExtractReferences parse Parsoid HTML and get all of it's references.
+func ExtractReferences(data []byte) ([]Reference, error) {
+	doc, err := Parse(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.References(), nil
+}
+
+type dataMWRef struct {
+	Attrs struct {
+		Name  string `json:"name"`
+		Group string `json:"group"`
+	} `json:"attrs"`
+}
+
+// References get all references of the document in the order of references lists.
+func (doc *Document) References() []Reference {
+	refs := []Reference{}
+	lookup := map[string]int{}
+
+	lists := FindAll(doc.Root, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "ol" && HasToken(Attr(n, "class"), "mw-references")
+	})
+
+	for _, list := range lists {
+		number := 0
+
+		for li := list.FirstChild; li != nil; li = li.NextSibling {
+			if li.Type != html.ElementNode || li.Data != "li" {
+				continue
+			}
+
+			number++
+			ref := Reference{
+				ID:     Attr(li, "id"),
+				Number: number,
+				Group:  Attr(list, "data-mw-group"),
+				Node:   li,
+			}
+
+			if body := Find(li, isReferenceText); body != nil {
+				ref.Text = CleanText(body)
+				ref.URLs = externalURLs(body)
+				ref.Citations = doc.citations(body)
+			}
+
+			lookup[ref.ID] = len(refs)
+			refs = append(refs, ref)
+		}
+	}
+
+	markers := FindAll(doc.Root, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && HasToken(Attr(n, "typeof"), "mw:Extension/ref")
+	})
+
+	for _, marker := range markers {
+		idx, ok := lookup[referenceID(marker)]
+
+		if !ok {
+			continue
+		}
+
+		ref := &refs[idx]
+		ref.Markers = append(ref.Markers, marker)
+		data := new(dataMWRef)
+
+		if err := json.Unmarshal([]byte(Attr(marker, "data-mw")), data); err == nil && len(ref.Name) == 0 {
+			ref.Name = data.Attrs.Name
+		}
+
+		if paragraph := paragraph(marker); paragraph != nil && !containsNode(ref.Paragraphs, paragraph) {
+			ref.Paragraphs = append(ref.Paragraphs, paragraph)
+		}
+	}
+
+	return refs
+}
+
+func (doc *Document) citations(n *html.Node) []Citation {
+	cits := []Citation{}
+
+	for _, tpl := range doc.Templates {
+		if tpl.Node != n && !isDescendant(tpl.Node, n) {
+			continue
+		}
+
+		cit := Citation{
+			Template: tpl.Name,
+			Params:   tpl.Params,
+		}
+
+		cit.Title = firstParam(&tpl, "title")
+		cit.Date = firstParam(&tpl, "date", "year")
+		cit.URL = firstParam(&tpl, "url")
+		cit.DOI = firstParam(&tpl, "doi", "DOI")
+		cit.ISBN = firstParam(&tpl, "isbn", "ISBN")
+		cit.ArchiveURL = firstParam(&tpl, "archive-url", "archiveurl")
+		cit.Author = firstParam(&tpl, "author", "author1")
+
+		if len(cit.Author) == 0 {
+			last, first := firstParam(&tpl, "last", "last1"), firstParam(&tpl, "first", "first1")
+
+			if len(last) > 0 && len(first) > 0 {
+				cit.Author = last + ", " + first
+			} else {
+				cit.Author = last
+			}
+		}
+
+		cits = append(cits, cit)
+	}
+
+	return cits
+}
+
+func firstParam(tpl *Template, names ...string) string {
+	for _, name := range names {
+		if value, ok := tpl.Param(name); ok && len(strings.TrimSpace(value)) > 0 {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
+
+// referenceID get id of the note ("cite_note-1") the ref marker points to.
+func referenceID(marker *html.Node) string {
+	a := Find(marker, isElement("a"))
+
+	if a == nil {
+		return ""
+	}
+
+	href := Attr(a, "href")
+
+	if idx := strings.Index(href, "#"); idx != -1 {
+		return href[idx+1:]
+	}
+
+	return ""
+}
+
+func isReferenceText(n *html.Node) bool {
+	return n.Type == html.ElementNode && HasToken(Attr(n, "class"), "mw-reference-text")
+}
+
+func externalURLs(n *html.Node) []string {
+	urls := []string{}
+
+	for _, a := range FindAll(n, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == "a" && HasToken(Attr(n, "rel"), "mw:ExtLink")
+	}) {
+		urls = append(urls, Attr(a, "href"))
+	}
+
+	return urls
+}
+
+// paragraph get closest block level ancestor of the node.
+func paragraph(n *html.Node) *html.Node {
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		if parent.Type != html.ElementNode {
+			continue
+		}
+
+		switch parent.Data {
+		case "p", "li", "dd", "td", "th", "figcaption", "section", "body":
+			return parent
+		}
+	}
+
+	return nil
+}
+
+func isDescendant(n *html.Node, ancestor *html.Node) bool {
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		if parent == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsNode(nodes []*html.Node, n *html.Node) bool {
+	for _, node := range nodes {
+		if node == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String get reference label as rendered in the text ("[1]" or "[note 1]").
+func (ref *Reference) String() string {
+	if len(ref.Group) > 0 {
+		return "[" + ref.Group + " " + strconv.Itoa(ref.Number) + "]"
+	}
+
+	return "[" + strconv.Itoa(ref.Number) + "]"
+}
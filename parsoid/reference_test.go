@@ -0,0 +1,43 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractReferences(t *testing.T) {
+	assert := assert.New(t)
+	refs, err := ExtractReferences([]byte(documentTestHTML))
+	assert.NoError(err)
+	assert.Len(refs, 2)
+
+	book := refs[0]
+	assert.Equal("cite_note-1", book.ID)
+	assert.Equal(1, book.Number)
+	assert.Equal("[1]", book.String())
+	assert.Equal("turnbull", book.Name)
+	assert.Equal("Turnbull, Stephen (2003). Ninja AD 1460–1650.", book.Text)
+	assert.Equal([]string{"https://example.org/book"}, book.URLs)
+	assert.Len(book.Markers, 2)
+	assert.Len(book.Paragraphs, 2)
+	assert.Equal("mwAw", Attr(book.Paragraphs[0], "id"))
+	assert.Equal("mwCA", Attr(book.Paragraphs[1], "id"))
+	assert.Len(book.Citations, 1)
+
+	citation := book.Citations[0]
+	assert.Equal("cite book", citation.Template)
+	assert.Equal("Ninja AD 1460–1650", citation.Title)
+	assert.Equal("Stephen Turnbull", citation.Author)
+	assert.Equal("2003", citation.Date)
+	assert.Equal("978-1-84176-525-9", citation.ISBN)
+	assert.Equal("https://example.org/book", citation.URL)
+
+	note := refs[1]
+	assert.Equal(2, note.Number)
+	assert.Empty(note.Name)
+	assert.Empty(note.Citations)
+	assert.Equal([]string{"https://example.org/note"}, note.URLs)
+	assert.Len(note.Paragraphs, 1)
+	assert.Equal("mwBg", Attr(note.Paragraphs[0], "id"))
+}
@@ -0,0 +1,76 @@
+package parsoid
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Section document section, represented by "<section data-mw-section-id>" element.
+// Lead section has level 0 and no heading, non editable pseudo sections have negative ids.
+type Section struct {
+	ID          int
+	Level       int
+	Heading     string
+	Anchor      string
+	Node        *html.Node
+	HeadingNode *html.Node
+	Parent      *Section
+	Children    []*Section
+}
+
+// Flatten get the section and all of it's descendants in document order.
+func (sec *Section) Flatten() []*Section {
+	sections := []*Section{sec}
+
+	for _, child := range sec.Children {
+		sections = append(sections, child.Flatten()...)
+	}
+
+	return sections
+}
+
+func (sec *Section) setHeading(n *html.Node) {
+	sec.HeadingNode = n
+	sec.Level = int(n.Data[1] - '0')
+	sec.Heading = strings.TrimSpace(Text(n))
+	sec.Anchor = Attr(n, "id")
+}
+
+// AllSections get all document sections in document order.
+func (doc *Document) AllSections() []*Section {
+	sections := []*Section{}
+
+	for _, sec := range doc.Sections {
+		sections = append(sections, sec.Flatten()...)
+	}
+
+	return sections
+}
+
+// Section find section by it's "data-mw-section-id".
+func (doc *Document) Section(id int) *Section {
+	for _, sec := range doc.AllSections() {
+		if sec.ID == id {
+			return sec
+		}
+	}
+
+	return nil
+}
+
+func (doc *Document) addSection(n *html.Node, id int, parent *Section) *Section {
+	sec := &Section{
+		ID:     id,
+		Node:   n,
+		Parent: parent,
+	}
+
+	if parent != nil {
+		parent.Children = append(parent.Children, sec)
+	} else {
+		doc.Sections = append(doc.Sections, sec)
+	}
+
+	return sec
+}
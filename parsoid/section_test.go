@@ -0,0 +1,35 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSections(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	lead := doc.Sections[0]
+	assert.Equal(0, lead.ID)
+	assert.Equal(0, lead.Level)
+	assert.Empty(lead.Heading)
+
+	history := doc.Sections[1]
+	assert.Equal(1, history.ID)
+	assert.Equal(2, history.Level)
+	assert.Equal("History", history.Heading)
+	assert.Equal("History", history.Anchor)
+	assert.Len(history.Children, 1)
+
+	origins := history.Children[0]
+	assert.Equal(2, origins.ID)
+	assert.Equal(3, origins.Level)
+	assert.Equal("Origins", origins.Heading)
+	assert.Equal(history, origins.Parent)
+
+	assert.Len(doc.AllSections(), 4)
+	assert.Equal(origins, doc.Section(2))
+	assert.Nil(doc.Section(10))
+}
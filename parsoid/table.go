@@ -0,0 +1,337 @@
+package parsoid
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTML limits of the cell spans.
+const (
+	maxRowSpan = 65534
+	maxColSpan = 1000
+)
+
+// Cell table cell, cells spanned by rowspan or colspan are repeated in every position they cover.
+type Cell struct {
+	Text    string
+	Header  bool
+	RowSpan int
+	ColSpan int
+	Spanned bool
+	Links   []Link
+	Node    *html.Node
+}
+
+// Table table normalized into rows and columns grid.
+type Table struct {
+	Caption string
+	Header  []string
+	Rows    [][]Cell
+	Node    *html.Node
+}
+
+// Tables get all document tables having one of the classes, "wikitable" by default.
+func (doc *Document) Tables(classes ...string) []Table {
+	if len(classes) == 0 {
+		classes = []string{"wikitable"}
+	}
+
+	tables := []Table{}
+	nodes := FindAll(doc.Root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.Data != "table" {
+			return false
+		}
+
+		for _, class := range classes {
+			if HasToken(Attr(n, "class"), class) {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	for _, n := range nodes {
+		tables = append(tables, NewTable(n))
+	}
+
+	return tables
+}
+
+// NewTable build table grid from "<table>" node resolving rowspan and colspan.
+// Rowspan is clipped to the rows left in its thead, tbody or tfoot group, rowspan="0" spans to the end of the group.
+func NewTable(n *html.Node) Table {
+	table := Table{Node: n}
+	grid := [][]Cell{}
+
+	for _, group := range tableRowGroups(n) {
+		groupStart := len(grid)
+		groupEnd := groupStart + len(group)
+
+		for len(grid) < groupEnd {
+			grid = append(grid, []Cell{})
+		}
+
+		for idx, tr := range group {
+			rowIdx := groupStart + idx
+			colIdx := 0
+
+			for td := tr.FirstChild; td != nil; td = td.NextSibling {
+				if td.Type != html.ElementNode || (td.Data != "td" && td.Data != "th") {
+					continue
+				}
+
+				for colIdx < len(grid[rowIdx]) && grid[rowIdx][colIdx].Node != nil {
+					colIdx++
+				}
+
+				cell := newCell(td)
+
+				if cell.RowSpan == 0 || rowIdx+cell.RowSpan > groupEnd {
+					cell.RowSpan = groupEnd - rowIdx
+				}
+
+				for r := 0; r < cell.RowSpan; r++ {
+					for c := 0; c < cell.ColSpan; c++ {
+						for len(grid[rowIdx+r]) <= colIdx+c {
+							grid[rowIdx+r] = append(grid[rowIdx+r], Cell{})
+						}
+
+						spanned := cell
+						spanned.Spanned = r > 0 || c > 0
+						grid[rowIdx+r][colIdx+c] = spanned
+					}
+				}
+
+				colIdx += cell.ColSpan
+			}
+		}
+	}
+
+	if caption := Find(n, isElement("caption")); caption != nil {
+		table.Caption = CleanText(caption)
+	}
+
+	if len(grid) > 0 && isHeaderRow(grid[0]) {
+		for _, cell := range grid[0] {
+			table.Header = append(table.Header, cell.Text)
+		}
+
+		grid = grid[1:]
+	}
+
+	table.Rows = grid
+	return table
+}
+
+// Records get table rows as maps keyed by header, columns without header are keyed by their index.
+func (tbl *Table) Records() []map[string]string {
+	records := []map[string]string{}
+
+	for _, row := range tbl.Rows {
+		record := map[string]string{}
+
+		for idx, cell := range row {
+			key := strconv.Itoa(idx)
+
+			if idx < len(tbl.Header) && len(tbl.Header[idx]) > 0 {
+				key = tbl.Header[idx]
+			}
+
+			record[key] = cell.Text
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// WriteCSV write table as CSV, header goes first if table has one.
+func (tbl *Table) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if len(tbl.Header) > 0 {
+		if err := writer.Write(tbl.Header); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range tbl.Rows {
+		record := []string{}
+
+		for _, cell := range row {
+			record = append(record, cell.Text)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON write table records as JSON array.
+func (tbl *Table) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(tbl.Records())
+}
+
+// newCell create cell from "<td>" or "<th>" node, spans are clamped to the HTML limits,
+// rowspan="0" is kept as zero to be resolved against the row group.
+func newCell(n *html.Node) Cell {
+	cell := Cell{
+		Text:    CleanText(n),
+		Header:  n.Data == "th",
+		RowSpan: 1,
+		ColSpan: 1,
+		Links:   links(n),
+		Node:    n,
+	}
+
+	if span, err := strconv.Atoi(Attr(n, "rowspan")); err == nil && (span == 0 || span > 1) {
+		cell.RowSpan = span
+	}
+
+	if cell.RowSpan > maxRowSpan {
+		cell.RowSpan = maxRowSpan
+	}
+
+	if span, err := strconv.Atoi(Attr(n, "colspan")); err == nil && span > 1 {
+		cell.ColSpan = span
+	}
+
+	if cell.ColSpan > maxColSpan {
+		cell.ColSpan = maxColSpan
+	}
+
+	return cell
+}
+
+func isHeaderRow(row []Cell) bool {
+	for _, cell := range row {
+		if !cell.Header {
+			return false
+		}
+	}
+
+	return len(row) > 0
+}
+
+// tableRowGroups get rows of the table grouped by thead, tbody and tfoot without descending into nested tables,
+// consecutive rows outside of the groups form a group of their own.
+func tableRowGroups(n *html.Node) [][]*html.Node {
+	groups := [][]*html.Node{}
+	rows := []*html.Node{}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		switch child.Data {
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			if len(rows) > 0 {
+				groups = append(groups, rows)
+				rows = []*html.Node{}
+			}
+
+			group := []*html.Node{}
+
+			for tr := child.FirstChild; tr != nil; tr = tr.NextSibling {
+				if tr.Type == html.ElementNode && tr.Data == "tr" {
+					group = append(group, tr)
+				}
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	if len(rows) > 0 {
+		groups = append(groups, rows)
+	}
+
+	return groups
+}
+
+// tableRows get rows of the table without descending into nested tables.
+func tableRows(n *html.Node) []*html.Node {
+	rows := []*html.Node{}
+
+	for _, group := range tableRowGroups(n) {
+		rows = append(rows, group...)
+	}
+
+	return rows
+}
+
+func links(n *html.Node) []Link {
+	lnks := []Link{}
+
+	for _, a := range FindAll(n, isWikiLink) {
+		lnks = append(lnks, newLink(a))
+	}
+
+	return lnks
+}
+
+func isWikiLink(n *html.Node) bool {
+	return n.Type == html.ElementNode && n.Data == "a" && HasToken(Attr(n, "rel"), "mw:WikiLink")
+}
+
+func isElement(name string) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == name
+	}
+}
+
+// CleanText get human readable text of the node: line breaks are kept,
+// whitespace is collapsed and reference markers, styles and scripts are skipped.
+func CleanText(n *html.Node) string {
+	buf := new(strings.Builder)
+	cleanText(n, buf)
+	lines := []string{}
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func cleanText(n *html.Node, buf *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		buf.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		switch n.Data {
+		case "style", "script", "link", "meta":
+			return
+		case "br":
+			buf.WriteString("\n")
+			return
+		case "p", "div", "li", "tr":
+			defer buf.WriteString("\n")
+		}
+
+		if HasToken(Attr(n, "typeof"), "mw:Extension/ref") {
+			return
+		}
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		cleanText(child, buf)
+	}
+}
@@ -0,0 +1,81 @@
+package parsoid
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tableTestCSV = "Clan,Province,Members\nIga,Iga,100\nIga,Unknown,Unknown\n"
+const tableTestJSON = `[{"Clan":"Iga","Members":"100","Province":"Iga"},{"Clan":"Iga","Members":"Unknown","Province":"Unknown"}]` + "\n"
+
+func TestTables(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	tables := doc.Tables()
+	assert.Len(tables, 1)
+
+	table := tables[0]
+	assert.Equal([]string{"Clan", "Province", "Members"}, table.Header)
+	assert.Len(table.Rows, 2)
+	assert.Len(table.Rows[1], 3)
+	assert.Equal(2, table.Rows[0][0].RowSpan)
+	assert.False(table.Rows[0][0].Spanned)
+	assert.True(table.Rows[1][0].Spanned)
+	assert.Equal("Iga", table.Rows[1][0].Text)
+	assert.Equal(2, table.Rows[1][1].ColSpan)
+	assert.True(table.Rows[1][2].Spanned)
+
+	csv := new(bytes.Buffer)
+	assert.NoError(table.WriteCSV(csv))
+	assert.Equal(tableTestCSV, csv.String())
+
+	json := new(bytes.Buffer)
+	assert.NoError(table.WriteJSON(json))
+	assert.Equal(tableTestJSON, json.String())
+
+	assert.Len(doc.Tables("infobox"), 1)
+}
+
+func TestTableSpans(t *testing.T) {
+	table := func(body string) Table {
+		doc, err := Parse([]byte(`<table class="wikitable">` + body + `</table>`))
+		assert.NoError(t, err)
+		tables := doc.Tables()
+		assert.Len(t, tables, 1)
+		return tables[0]
+	}
+
+	t.Run("rowspan clipped to group", func(t *testing.T) {
+		tbl := table(`<tbody><tr><td rowspan="5">a</td><td>b</td></tr><tr><td>c</td></tr></tbody>` +
+			`<tfoot><tr><td>d</td><td>e</td></tr></tfoot>`)
+		assert.Len(t, tbl.Rows, 3)
+		assert.Equal(t, 2, tbl.Rows[0][0].RowSpan)
+		assert.Equal(t, []string{"a", "c"}, []string{tbl.Rows[1][0].Text, tbl.Rows[1][1].Text})
+		assert.Equal(t, []string{"d", "e"}, []string{tbl.Rows[2][0].Text, tbl.Rows[2][1].Text})
+	})
+
+	t.Run("rowspan zero spans to end of group", func(t *testing.T) {
+		tbl := table(`<tbody><tr><td rowspan="0">a</td><td>b</td></tr><tr><td>c</td></tr><tr><td>d</td></tr></tbody>` +
+			`<tfoot><tr><td>e</td></tr></tfoot>`)
+		assert.Len(t, tbl.Rows, 4)
+		assert.Equal(t, 3, tbl.Rows[0][0].RowSpan)
+		assert.True(t, tbl.Rows[2][0].Spanned)
+		assert.Equal(t, "d", tbl.Rows[2][1].Text)
+		assert.Equal(t, "e", tbl.Rows[3][0].Text)
+	})
+
+	t.Run("spans clamped", func(t *testing.T) {
+		tbl := table(`<tbody><tr><td rowspan="99999999" colspan="99999999">a</td></tr></tbody>`)
+		assert.Len(t, tbl.Rows, 1)
+		assert.Len(t, tbl.Rows[0], maxColSpan)
+		assert.Equal(t, 1, tbl.Rows[0][0].RowSpan)
+
+		cell := newCell(Find(tbl.Node, isElement("td")))
+		assert.Equal(t, maxRowSpan, cell.RowSpan)
+		assert.Equal(t, maxColSpan, cell.ColSpan)
+	})
+}
@@ -0,0 +1,143 @@
+package parsoid
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Param template invocation parameter, positional parameters are named by their index ("1", "2").
+type Param struct {
+	Name  string
+	Value string
+	HTML  string
+}
+
+// Params template parameters in the order of invocation.
+type Params []Param
+
+// UnmarshalJSON decode "params" object of data-mw preserving parameters order.
+func (params *Params) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+
+		if err != nil {
+			return err
+		}
+
+		value := struct {
+			Wt   string `json:"wt"`
+			HTML string `json:"html"`
+		}{}
+
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		name, _ := key.(string)
+		*params = append(*params, Param{Name: name, Value: value.Wt, HTML: value.HTML})
+	}
+
+	return nil
+}
+
+// Template template or parser function invocation decoded from "data-mw".
+type Template struct {
+	Name     string
+	Target   string
+	Function string
+	Params   Params
+	Index    int
+	About    string
+	Node     *html.Node
+}
+
+// Param get template parameter value by name.
+func (tpl *Template) Param(name string) (string, bool) {
+	for _, param := range tpl.Params {
+		if param.Name == name {
+			return param.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// IsParserFunction check if invocation is parser function (like "#if") rather than template.
+func (tpl *Template) IsParserFunction() bool {
+	return len(tpl.Function) > 0
+}
+
+type dataMWTarget struct {
+	Wt       string `json:"wt"`
+	Href     string `json:"href"`
+	Function string `json:"function"`
+}
+
+type dataMWTemplate struct {
+	Target dataMWTarget `json:"target"`
+	Params Params       `json:"params"`
+	I      int          `json:"i"`
+}
+
+type dataMWPart struct {
+	Template       *dataMWTemplate `json:"template"`
+	ParserFunction *dataMWTemplate `json:"parserfunction"`
+}
+
+type dataMW struct {
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func newTemplates(n *html.Node) []Template {
+	tpls := []Template{}
+	data := new(dataMW)
+
+	if err := json.Unmarshal([]byte(Attr(n, "data-mw")), data); err != nil {
+		return tpls
+	}
+
+	for _, raw := range data.Parts {
+		part := new(dataMWPart)
+
+		// plain wikitext between the templates comes as a string
+		if err := json.Unmarshal(raw, part); err != nil {
+			continue
+		}
+
+		tpl := part.Template
+
+		if tpl == nil {
+			tpl = part.ParserFunction
+		}
+
+		if tpl == nil {
+			continue
+		}
+
+		invocation := Template{
+			Name:     strings.TrimSpace(tpl.Target.Wt),
+			Function: tpl.Target.Function,
+			Params:   tpl.Params,
+			Index:    tpl.I,
+			About:    Attr(n, "about"),
+			Node:     n,
+		}
+
+		if len(tpl.Target.Href) > 0 {
+			invocation.Target, _ = TitleFromHref(tpl.Target.Href)
+		}
+
+		tpls = append(tpls, invocation)
+	}
+
+	return tpls
+}
@@ -0,0 +1,41 @@
+package parsoid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(documentTestHTML))
+	assert.NoError(err)
+
+	assert.Len(doc.Templates, 2)
+
+	infobox := doc.Templates[0]
+	assert.Equal("Infobox person", infobox.Name)
+	assert.Equal("Template:Infobox person", infobox.Target)
+	assert.Equal("#mwt1", infobox.About)
+	assert.False(infobox.IsParserFunction())
+	assert.Equal([]string{"name", "birth_date", "1"}, []string{infobox.Params[0].Name, infobox.Params[1].Name, infobox.Params[2].Name})
+
+	value, ok := infobox.Param("birth_date")
+	assert.True(ok)
+	assert.Equal("1542", value)
+
+	_, ok = infobox.Param("death_date")
+	assert.False(ok)
+
+	assert.Equal("Template:Cite book", doc.Templates[1].Target)
+}
+
+func TestParserFunction(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := Parse([]byte(`<span about="#mwt1" typeof="mw:Transclusion" data-mw='{"parts":["prefix ",{"template":{"target":{"wt":"#if:x","function":"if"},"params":{"1":{"wt":"yes"}},"i":0}}]}'>yes</span>`))
+	assert.NoError(err)
+
+	assert.Len(doc.Templates, 1)
+	assert.True(doc.Templates[0].IsParserFunction())
+	assert.Equal("if", doc.Templates[0].Function)
+}
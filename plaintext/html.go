@@ -0,0 +1,218 @@
+package plaintext
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var blockTags = map[string]bool{
+	"p":          true,
+	"div":        true,
+	"li":         true,
+	"dd":         true,
+	"dt":         true,
+	"ul":         true,
+	"ol":         true,
+	"dl":         true,
+	"blockquote": true,
+	"section":    true,
+	"table":      true,
+	"tr":         true,
+	"td":         true,
+	"th":         true,
+	"caption":    true,
+	"figure":     true,
+	"figcaption": true,
+	"pre":        true,
+}
+
+var voidTags = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+var skipTags = map[string]bool{
+	"head":     true,
+	"style":    true,
+	"script":   true,
+	"noscript": true,
+}
+
+type htmlAttrs struct {
+	class  string
+	typeof string
+	role   string
+}
+
+// FromHTML render page HTML (Parsoid or legacy parser output) into plain text.
+func FromHTML(data []byte, opts ...Options) (*Document, error) {
+	opt := options(opts)
+	b := newBuilder()
+	z := html.NewTokenizer(bytes.NewReader(data))
+	offset := 0
+	skipTag, skipDepth := "", 0
+	headingLevel, headingOffset := 0, 0
+	var heading *strings.Builder
+
+	for {
+		tt := z.Next()
+		start := offset
+		offset += len(z.Raw())
+
+		if tt == html.ErrorToken {
+			if z.Err() == io.EOF {
+				return b.document(), nil
+			}
+
+			return nil, z.Err()
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			void := tt == html.SelfClosingTagToken || voidTags[tag]
+
+			if skipDepth > 0 {
+				if tag == skipTag && !void {
+					skipDepth++
+				}
+
+				continue
+			}
+
+			if skip(tag, readAttrs(z, hasAttr), opt) {
+				if !void {
+					skipTag, skipDepth = tag, 1
+				}
+
+				continue
+			}
+
+			if level := headingTag(tag); level > 0 && !void {
+				b.paragraph()
+				heading, headingLevel, headingOffset = new(strings.Builder), level, start
+			} else if blockTags[tag] {
+				b.paragraph()
+			} else if tag == "br" && heading == nil {
+				b.text(" ", start, offset-start)
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+
+			if skipDepth > 0 {
+				if tag == skipTag {
+					skipDepth--
+				}
+
+				continue
+			}
+
+			if heading != nil && headingTag(tag) == headingLevel {
+				b.heading(heading.String(), headingLevel, headingOffset)
+				heading = nil
+			} else if blockTags[tag] {
+				b.paragraph()
+			}
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+
+			if heading != nil {
+				heading.Write(z.Text())
+			} else {
+				b.text(string(z.Text()), start, offset-start)
+			}
+		}
+	}
+}
+
+func readAttrs(z *html.Tokenizer, hasAttr bool) htmlAttrs {
+	attrs := htmlAttrs{}
+
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = z.TagAttr()
+
+		switch string(key) {
+		case "class":
+			attrs.class = string(val)
+		case "typeof":
+			attrs.typeof = string(val)
+		case "role":
+			attrs.role = string(val)
+		}
+	}
+
+	return attrs
+}
+
+func skip(tag string, attrs htmlAttrs, opt Options) bool {
+	if skipTags[tag] {
+		return true
+	}
+
+	classes := strings.Fields(attrs.class)
+	types := strings.Fields(attrs.typeof)
+
+	switch {
+	case contains(types, "mw:Extension/ref"), contains(types, "mw:Extension/references"),
+		contains(classes, "reference"), contains(classes, "mw-references-wrap"),
+		contains(classes, "reflist"), contains(classes, "references"):
+		return !opt.KeepReferences
+	case hasPrefix(classes, "navbox"), contains(classes, "vertical-navbox"), attrs.role == "navigation":
+		return !opt.KeepNavboxes
+	case hasPrefix(classes, "infobox"):
+		return !opt.KeepInfoboxes
+	case contains(classes, "hatnote"), contains(classes, "dablink"), contains(classes, "rellink"), attrs.role == "note":
+		return !opt.KeepHatnotes
+	case tag == "table":
+		return !opt.KeepTables
+	}
+
+	return false
+}
+
+func headingTag(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasPrefix(values []string, prefix string) bool {
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package plaintext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const htmlTestSource = `<!DOCTYPE html>
+<html><head><title>Ninja</title><style>.a{}</style></head>
+<body>
+<section data-mw-section-id="0">
+<div role="note" class="hatnote navigation-not-searchable">For other uses, see <a href="./Ninja_(disambiguation)">Ninja (disambiguation)</a>.</div>
+<table class="infobox"><tr><th>Born</th><td>1542</td></tr></table>
+<p>A <b>ninja</b> was a covert agent&nbsp;in <a rel="mw:WikiLink" href="./Feudal_Japan">feudal Japan</a>.<sup typeof="mw:Extension/ref" class="mw-ref reference"><a href="#cite_note-1">[1]</a></sup></p>
+<p>
+</p>
+</section>
+<section data-mw-section-id="1"><h2 id="History">History</h2>
+<ul><li>first item</li><li>second<br/>item</li></ul>
+<table class="wikitable"><tr><td>cell</td></tr></table>
+<div role="navigation" class="navbox">Navigation box</div>
+<div class="mw-references-wrap" typeof="mw:Extension/references"><ol><li>Turnbull</li></ol></div>
+</section>
+</body></html>`
+
+func TestFromHTML(t *testing.T) {
+	assert := assert.New(t)
+	doc, err := FromHTML([]byte(htmlTestSource))
+	assert.NoError(err)
+
+	assert.Len(doc.Sections, 2)
+	assert.Len(doc.Sections[0].Paragraphs, 1)
+	assert.Equal("A ninja was a covert agent in feudal Japan.", doc.Sections[0].Paragraphs[0].Text())
+
+	history := doc.Sections[1]
+	assert.Equal("History", history.Title)
+	assert.Equal(2, history.Level)
+	assert.True(strings.HasPrefix(htmlTestSource[history.Offset:], "<h2"))
+	assert.Len(history.Paragraphs, 2)
+	assert.Equal("second item", history.Paragraphs[1].Text())
+
+	span := doc.Sections[0].Paragraphs[0].Spans[1]
+	assert.Equal("ninja", span.Text)
+	assert.Equal("ninja", htmlTestSource[span.Offset:span.Offset+span.Length])
+
+	assert.Equal("A ninja was a covert agent in feudal Japan.\n\nHistory\n\nfirst item\n\nsecond item", doc.String())
+
+	doc, err = FromHTML([]byte(htmlTestSource), Options{
+		KeepNavboxes:   true,
+		KeepReferences: true,
+		KeepInfoboxes:  true,
+		KeepTables:     true,
+		KeepHatnotes:   true,
+	})
+	assert.NoError(err)
+
+	text := doc.String()
+	assert.Contains(text, "For other uses")
+	assert.Contains(text, "1542")
+	assert.Contains(text, "[1]")
+	assert.Contains(text, "cell")
+	assert.Contains(text, "Navigation box")
+	assert.Contains(text, "Turnbull")
+}
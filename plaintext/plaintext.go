@@ -0,0 +1,175 @@
+// Package plaintext renders page HTML or wikitext into clean plain text split into sections
+// and paragraphs, every text span keeps the offset of it's source markup.
+package plaintext
+
+import (
+	"strings"
+)
+
+// Options controls which parts of the article are kept, by default all of them are stripped.
+type Options struct {
+	KeepNavboxes   bool
+	KeepReferences bool
+	KeepInfoboxes  bool
+	KeepTables     bool
+	KeepHatnotes   bool
+}
+
+// Span piece of text together with byte offset and length of the source markup it came from.
+type Span struct {
+	Text   string
+	Offset int
+	Length int
+}
+
+// Paragraph sequence of text spans forming single paragraph or list item.
+type Paragraph struct {
+	Spans []Span
+}
+
+// Text get paragraph text.
+func (par *Paragraph) Text() string {
+	buf := new(strings.Builder)
+
+	for _, span := range par.Spans {
+		buf.WriteString(span.Text)
+	}
+
+	return buf.String()
+}
+
+// Section article section, first section is the lead with level 0 and no title.
+type Section struct {
+	Title      string
+	Level      int
+	Offset     int
+	Paragraphs []Paragraph
+}
+
+// Document plain text of the article.
+type Document struct {
+	Sections []Section
+}
+
+// String get text of the whole document, paragraphs are separated by blank lines
+// and section titles are put on their own lines.
+func (doc *Document) String() string {
+	blocks := []string{}
+
+	for _, sec := range doc.Sections {
+		if len(sec.Title) > 0 {
+			blocks = append(blocks, sec.Title)
+		}
+
+		for _, par := range sec.Paragraphs {
+			blocks = append(blocks, par.Text())
+		}
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+type builder struct {
+	doc *Document
+	par *Paragraph
+}
+
+func newBuilder() *builder {
+	return &builder{
+		doc: &Document{Sections: []Section{{}}},
+		par: new(Paragraph),
+	}
+}
+
+func (b *builder) section() *Section {
+	return &b.doc.Sections[len(b.doc.Sections)-1]
+}
+
+// text append text to the current paragraph collapsing whitespace.
+func (b *builder) text(text string, offset int, length int) {
+	text = collapse(text)
+
+	if len(b.par.Spans) == 0 || strings.HasSuffix(b.par.Spans[len(b.par.Spans)-1].Text, " ") {
+		text = strings.TrimLeft(text, " ")
+	}
+
+	if len(text) == 0 {
+		return
+	}
+
+	b.par.Spans = append(b.par.Spans, Span{
+		Text:   text,
+		Offset: offset,
+		Length: length,
+	})
+}
+
+// paragraph finish current paragraph, empty paragraphs are dropped.
+func (b *builder) paragraph() {
+	for len(b.par.Spans) > 0 {
+		last := &b.par.Spans[len(b.par.Spans)-1]
+		last.Text = strings.TrimRight(last.Text, " ")
+
+		if len(last.Text) > 0 {
+			break
+		}
+
+		b.par.Spans = b.par.Spans[:len(b.par.Spans)-1]
+	}
+
+	if len(b.par.Spans) > 0 {
+		sec := b.section()
+		sec.Paragraphs = append(sec.Paragraphs, *b.par)
+	}
+
+	b.par = new(Paragraph)
+}
+
+func (b *builder) heading(title string, level int, offset int) {
+	b.paragraph()
+	b.doc.Sections = append(b.doc.Sections, Section{
+		Title:  strings.TrimSpace(collapse(title)),
+		Level:  level,
+		Offset: offset,
+	})
+}
+
+func (b *builder) document() *Document {
+	b.paragraph()
+	return b.doc
+}
+
+func collapse(text string) string {
+	buf := new(strings.Builder)
+	space := false
+
+	for _, r := range text {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ' ' {
+			space = true
+			continue
+		}
+
+		if space {
+			buf.WriteByte(' ')
+			space = false
+		}
+
+		buf.WriteRune(r)
+	}
+
+	if space {
+		buf.WriteByte(' ')
+	}
+
+	return buf.String()
+}
+
+func options(opts []Options) Options {
+	opt := Options{}
+
+	for _, o := range opts {
+		opt = o
+	}
+
+	return opt
+}
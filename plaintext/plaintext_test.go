@@ -0,0 +1,38 @@
+package plaintext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	assert := assert.New(t)
+	b := newBuilder()
+	b.text("  Hello\n\tworld ", 0, 15)
+	b.text(" again ", 15, 7)
+	b.paragraph()
+	b.paragraph()
+	b.heading(" Next\nsection ", 2, 22)
+	b.text("Text", 40, 4)
+	doc := b.document()
+
+	assert.Len(doc.Sections, 2)
+	assert.Len(doc.Sections[0].Paragraphs, 1)
+
+	par := doc.Sections[0].Paragraphs[0]
+	assert.Equal("Hello world again", par.Text())
+	assert.Equal(Span{Text: "Hello world ", Offset: 0, Length: 15}, par.Spans[0])
+	assert.Equal(Span{Text: "again", Offset: 15, Length: 7}, par.Spans[1])
+
+	assert.Equal("Next section", doc.Sections[1].Title)
+	assert.Equal(2, doc.Sections[1].Level)
+	assert.Equal(22, doc.Sections[1].Offset)
+	assert.Equal("Hello world again\n\nNext section\n\nText", doc.String())
+}
+
+func TestCollapse(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(" a b ", collapse(" \na \t b\r\n"))
+	assert.Equal("", collapse(""))
+}
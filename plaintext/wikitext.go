@@ -0,0 +1,164 @@
+package plaintext
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/protsack-stephan/mediawiki-api-client/wikitext"
+)
+
+// wikitextMarkupRx markup left inside plain text nodes: bold and italic quotes, html tags and behavior switches.
+var wikitextMarkupRx = regexp.MustCompile(`'{2,}|</?[a-zA-Z][^<>\n]*>|__[A-Z]+__`)
+
+var paragraphBreakRx = regexp.MustCompile(`\n[ \t]*\n`)
+
+// FromWikitext render wikitext into plain text. Templates can't be expanded offline and are dropped,
+// so infoboxes, navboxes and hatnotes are never present in the output, references and tables are
+// kept depending on the options.
+func FromWikitext(data []byte, opts ...Options) *Document {
+	r := &wikitextRenderer{
+		b:   newBuilder(),
+		opt: options(opts),
+	}
+
+	r.nodes(wikitext.Parse(data).Nodes, 0)
+	return r.b.document()
+}
+
+type wikitextRenderer struct {
+	b   *builder
+	opt Options
+}
+
+// nodes render nodes starting at offset and get the offset right after them.
+func (r *wikitextRenderer) nodes(nodes wikitext.Nodes, offset int) int {
+	for _, node := range nodes {
+		r.node(node, offset)
+		offset += len(node.String())
+	}
+
+	return offset
+}
+
+func (r *wikitextRenderer) node(node wikitext.Node, offset int) {
+	switch node := node.(type) {
+	case *wikitext.Text:
+		r.text(node.Value, offset)
+	case *wikitext.Heading:
+		r.b.heading(r.plain(node.Title), node.Level, offset)
+	case *wikitext.WikiLink:
+		r.wikiLink(node, offset)
+	case *wikitext.ExternalLink:
+		if node.Bare {
+			r.b.text(node.URL, offset, len(node.URL))
+		} else if len(node.Text) > 0 {
+			r.nodes(node.Text, offset+1+len(node.URL)+len(node.Separator))
+		}
+	case *wikitext.Tag:
+		r.tag(node, offset)
+	case *wikitext.ListItem:
+		r.b.paragraph()
+		r.nodes(node.Content, offset+len(node.Prefix))
+		r.b.paragraph()
+	case *wikitext.Table:
+		r.b.paragraph()
+
+		if r.opt.KeepTables {
+			r.table(node, offset)
+		}
+	}
+}
+
+// text render plain text splitting it into paragraphs on blank lines and dropping leftover markup.
+func (r *wikitextRenderer) text(text string, offset int) {
+	last := 0
+
+	for _, brk := range paragraphBreakRx.FindAllStringIndex(text, -1) {
+		r.segment(text[last:brk[0]], offset+last)
+		r.b.paragraph()
+		last = brk[1]
+	}
+
+	r.segment(text[last:], offset+last)
+}
+
+func (r *wikitextRenderer) segment(text string, offset int) {
+	last := 0
+
+	for _, markup := range wikitextMarkupRx.FindAllStringIndex(text, -1) {
+		if markup[0] > last {
+			r.b.text(text[last:markup[0]], offset+last, markup[0]-last)
+		}
+
+		last = markup[1]
+	}
+
+	if len(text) > last {
+		r.b.text(text[last:], offset+last, len(text)-last)
+	}
+}
+
+func (r *wikitextRenderer) wikiLink(lnk *wikitext.WikiLink, offset int) {
+	if lnk.IsCategory() || lnk.IsFile() {
+		return
+	}
+
+	target := lnk.Target.String()
+
+	if len(lnk.Parts) == 0 {
+		text := strings.TrimPrefix(strings.TrimSpace(target), ":")
+		r.b.text(text, offset+2, len(target))
+		return
+	}
+
+	offset += 2 + len(target)
+
+	for _, part := range lnk.Parts[:len(lnk.Parts)-1] {
+		offset += 1 + len(part.String())
+	}
+
+	r.nodes(lnk.Parts[len(lnk.Parts)-1], offset+1)
+}
+
+func (r *wikitextRenderer) tag(tag *wikitext.Tag, offset int) {
+	switch tag.Name {
+	case "ref":
+		if r.opt.KeepReferences && !tag.SelfClosing() {
+			r.nodes(tag.Children, offset+len(tag.Open))
+		}
+	case "nowiki", "pre":
+		r.b.text(tag.Content, offset+len(tag.Open), len(tag.Content))
+	case "poem", "onlyinclude", "noinclude":
+		r.nodes(tag.Children, offset+len(tag.Open))
+	}
+}
+
+func (r *wikitextRenderer) table(tbl *wikitext.Table, offset int) {
+	offset += 2 + len(tbl.Attrs.String())
+
+	for _, item := range tbl.Items {
+		if cell, ok := item.(*wikitext.TableCell); ok {
+			content := offset + len(cell.Marker)
+
+			if cell.HasAttrs {
+				content += len(cell.Attrs.String()) + 1
+			}
+
+			r.nodes(cell.Content, content)
+			r.b.paragraph()
+		}
+
+		offset += len(item.String())
+	}
+}
+
+// plain render nodes into single line of text.
+func (r *wikitextRenderer) plain(nodes wikitext.Nodes) string {
+	sub := &wikitextRenderer{
+		b:   newBuilder(),
+		opt: r.opt,
+	}
+
+	sub.nodes(nodes, 0)
+	return collapse(sub.b.document().String())
+}
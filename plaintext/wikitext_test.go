@@ -0,0 +1,52 @@
+package plaintext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wikitextTestSource = `{{Short description|Covert agent}}
+{{Infobox person|name=Hanzō}}
+A '''ninja''' was a covert agent in [[feudal Japan|Japan]].<ref>{{cite book|title=Ninja}} Turnbull</ref>
+See [https://example.org external source].
+
+Second paragraph __NOTOC__ with <small>small</small> text.
+== [[History]] ==
+* first item
+* second item
+{|
+| cell
+|}
+[[Category:Ninja]]
+`
+
+func TestFromWikitext(t *testing.T) {
+	assert := assert.New(t)
+	doc := FromWikitext([]byte(wikitextTestSource))
+
+	assert.Len(doc.Sections, 2)
+	assert.Len(doc.Sections[0].Paragraphs, 2)
+	assert.Equal("A ninja was a covert agent in Japan. See external source.", doc.Sections[0].Paragraphs[0].Text())
+	assert.Equal("Second paragraph with small text.", doc.Sections[0].Paragraphs[1].Text())
+
+	history := doc.Sections[1]
+	assert.Equal("History", history.Title)
+	assert.Equal(2, history.Level)
+	assert.Len(history.Paragraphs, 2)
+	assert.Equal("first item", history.Paragraphs[0].Text())
+
+	for _, sec := range doc.Sections {
+		for _, par := range sec.Paragraphs {
+			for _, span := range par.Spans {
+				source := wikitextTestSource[span.Offset : span.Offset+span.Length]
+				assert.Contains(collapse(source), span.Text)
+			}
+		}
+	}
+
+	doc = FromWikitext([]byte(wikitextTestSource), Options{KeepReferences: true, KeepTables: true})
+	assert.Equal("A ninja was a covert agent in Japan. Turnbull See external source.", doc.Sections[0].Paragraphs[0].Text())
+	assert.Len(doc.Sections[1].Paragraphs, 3)
+	assert.Equal("cell", doc.Sections[1].Paragraphs[2].Text())
+}
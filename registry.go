@@ -0,0 +1,414 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSiteNotFound site is not present in the sitematrix.
+var ErrSiteNotFound = errors.New("site not found")
+
+// registryProjectAliases project names that differ from sitematrix site codes.
+var registryProjectAliases = map[string]string{
+	"wikipedia": "wiki",
+}
+
+// RegistryOptions optional settings for the registry, every client created by the registry
+// shares the http client (transport, cookies and auth), headers, endpoint options and the rate limit.
+// RateLimit is the minimal interval between two requests, RefreshInterval is the max age of the sitematrix.
+// Refresh is lazy: expired sitematrix is reloaded by the next call that needs it, with zero interval it's loaded once,
+// use StartRefresh to reload it periodically in the background. Failed reload keeps the loaded sitematrix
+// and is retried after another interval.
+type RegistryOptions struct {
+	HTTPClient      *http.Client
+	Headers         map[string]string
+	Options         *Options
+	RateLimit       time.Duration
+	RefreshInterval time.Duration
+}
+
+// RegistrySite single wiki from the sitematrix, both regular sites and specials.
+type RegistrySite struct {
+	DBName    string
+	URL       string
+	Host      string
+	Lang      string
+	Code      string
+	Sitename  string
+	Closed    bool
+	Private   bool
+	Fishbowl  bool
+	Nonglobal bool
+	Special   bool
+}
+
+// SiteFilter decide if the site should be included into the result.
+type SiteFilter func(site *RegistrySite) bool
+
+// ExcludeClosed filter out closed (read only) wikis.
+func ExcludeClosed(site *RegistrySite) bool {
+	return !site.Closed
+}
+
+// ExcludePrivate filter out private wikis.
+func ExcludePrivate(site *RegistrySite) bool {
+	return !site.Private
+}
+
+// ExcludeFishbowl filter out fishbowl wikis (only a limited group of users can edit).
+func ExcludeFishbowl(site *RegistrySite) bool {
+	return !site.Fishbowl
+}
+
+// Registry lazily creates and caches clients for all the wikis listed in the sitematrix.
+type Registry struct {
+	source     *Client
+	httpClient *http.Client
+	headers    map[string]string
+	options    *Options
+	refresh    time.Duration
+	mu         sync.Mutex
+	loaded     time.Time
+	checked    time.Time
+	sites      []*RegistrySite
+	byDBName   map[string]*RegistrySite
+	byHost     map[string]*RegistrySite
+	byLang     map[string]*RegistrySite
+	clients    map[string]*Client
+}
+
+// NewRegistry create registry using the client to fetch sitematrix, by default the settings of this client are shared.
+func NewRegistry(source *Client, options ...RegistryOptions) *Registry {
+	reg := &Registry{
+		source:     source,
+		httpClient: source.httpClient,
+		headers:    source.headers,
+		options:    source.options,
+		clients:    map[string]*Client{},
+	}
+
+	for _, opt := range options {
+		if opt.HTTPClient != nil {
+			reg.httpClient = opt.HTTPClient
+		}
+
+		if opt.Headers != nil {
+			reg.headers = opt.Headers
+		}
+
+		if opt.Options != nil {
+			reg.options = opt.Options
+		}
+
+		if opt.RateLimit > 0 {
+			client := *reg.httpClient
+			client.Transport = newRateLimitTransport(client.Transport, opt.RateLimit)
+			reg.httpClient = &client
+		}
+
+		if opt.RefreshInterval > 0 {
+			reg.refresh = opt.RefreshInterval
+		}
+	}
+
+	return reg
+}
+
+// Refresh reload sitematrix, clients for sites that are still present are kept.
+func (reg *Registry) Refresh(ctx context.Context) error {
+	matrix, err := reg.source.Sitematrix(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.index(matrix)
+	return nil
+}
+
+// StartRefresh reload sitematrix every RefreshInterval in the background until the context is done.
+// Errors are ignored, the loaded sitematrix is kept until the next successful reload. Nothing is started with zero interval.
+func (reg *Registry) StartRefresh(ctx context.Context) {
+	if reg.refresh <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(reg.refresh)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = reg.Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// Sites get all the sites passing the filters, sorted by dbname.
+func (reg *Registry) Sites(ctx context.Context, filters ...SiteFilter) ([]RegistrySite, error) {
+	if err := reg.load(ctx); err != nil {
+		return nil, err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	sites := []RegistrySite{}
+
+out:
+	for _, site := range reg.sites {
+		for _, filter := range filters {
+			if !filter(site) {
+				continue out
+			}
+		}
+
+		sites = append(sites, *site)
+	}
+
+	return sites, nil
+}
+
+// Site find site by dbname ("enwiki") or hostname ("en.wikipedia.org").
+func (reg *Registry) Site(ctx context.Context, key string) (RegistrySite, error) {
+	if err := reg.load(ctx); err != nil {
+		return RegistrySite{}, err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	site := reg.find(key)
+
+	if site == nil {
+		return RegistrySite{}, ErrSiteNotFound
+	}
+
+	return *site, nil
+}
+
+// Client get client by dbname ("enwiki") or hostname ("en.wikipedia.org").
+func (reg *Registry) Client(ctx context.Context, key string) (*Client, error) {
+	if err := reg.load(ctx); err != nil {
+		return nil, err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	site := reg.find(key)
+
+	if site == nil {
+		return nil, ErrSiteNotFound
+	}
+
+	return reg.client(site), nil
+}
+
+// ClientFor get client by language and project code, for example ("en", "wiktionary") or ("de", "wikipedia").
+// Special wikis are found by their code with empty language ("", "commons").
+func (reg *Registry) ClientFor(ctx context.Context, lang string, project string) (*Client, error) {
+	if err := reg.load(ctx); err != nil {
+		return nil, err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	site := reg.byLang[langKey(lang, project)]
+
+	if site == nil {
+		return nil, ErrSiteNotFound
+	}
+
+	return reg.client(site), nil
+}
+
+// load get sitematrix if it's not loaded or expired, stale sitematrix is served when the reload fails.
+func (reg *Registry) load(ctx context.Context) error {
+	reg.mu.Lock()
+	loaded, checked := reg.loaded, reg.checked
+	reg.mu.Unlock()
+
+	if !loaded.IsZero() && (reg.refresh <= 0 || time.Since(checked) < reg.refresh) {
+		return nil
+	}
+
+	err := reg.Refresh(ctx)
+
+	if err == nil || loaded.IsZero() {
+		return err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.checked = time.Now()
+	return nil
+}
+
+func (reg *Registry) index(matrix *Sitematrix) {
+	reg.sites = []*RegistrySite{}
+	reg.byDBName = map[string]*RegistrySite{}
+	reg.byHost = map[string]*RegistrySite{}
+	reg.byLang = map[string]*RegistrySite{}
+
+	for _, project := range matrix.Projects {
+		for _, site := range project.Site {
+			reg.add(&RegistrySite{
+				DBName:   site.DBName,
+				URL:      site.URL,
+				Lang:     project.Code,
+				Code:     site.Code,
+				Sitename: site.Sitename,
+				Closed:   site.Closed,
+			})
+		}
+	}
+
+	for _, special := range matrix.Specials {
+		reg.add(&RegistrySite{
+			DBName:    special.DBName,
+			URL:       special.URL,
+			Lang:      special.Lang,
+			Code:      special.Code,
+			Sitename:  special.Sitename,
+			Closed:    special.Closed,
+			Private:   special.Private,
+			Fishbowl:  special.Fishbowl,
+			Nonglobal: special.Nonglobal,
+			Special:   true,
+		})
+	}
+
+	sort.Slice(reg.sites, func(i, j int) bool {
+		return reg.sites[i].DBName < reg.sites[j].DBName
+	})
+
+	for dbName := range reg.clients {
+		if _, ok := reg.byDBName[dbName]; !ok {
+			delete(reg.clients, dbName)
+		}
+	}
+
+	reg.loaded = time.Now()
+	reg.checked = reg.loaded
+}
+
+func (reg *Registry) add(site *RegistrySite) {
+	if u, err := url.Parse(site.URL); err == nil {
+		site.Host = u.Hostname()
+	}
+
+	reg.sites = append(reg.sites, site)
+	reg.byDBName[site.DBName] = site
+
+	if len(site.Host) > 0 {
+		reg.byHost[site.Host] = site
+	}
+
+	// specials don't have language code, the site code is unique enough ("commons", "meta")
+	if site.Special {
+		if _, ok := reg.byLang[langKey("", site.Code)]; !ok {
+			reg.byLang[langKey("", site.Code)] = site
+		}
+	} else {
+		reg.byLang[langKey(site.Lang, site.Code)] = site
+	}
+}
+
+func (reg *Registry) find(key string) *RegistrySite {
+	if site, ok := reg.byDBName[key]; ok {
+		return site
+	}
+
+	if u, err := url.Parse(key); err == nil && len(u.Host) > 0 {
+		key = u.Hostname()
+	}
+
+	return reg.byHost[strings.ToLower(key)]
+}
+
+func (reg *Registry) client(site *RegistrySite) *Client {
+	if client, ok := reg.clients[site.DBName]; ok {
+		return client
+	}
+
+	client := NewBuilder(site.URL).
+		HTTPClient(reg.httpClient).
+		Headers(reg.headers).
+		Options(reg.options).
+		SiteInfoTTL(reg.source.siteInfoTTL).
+		Build()
+
+	reg.clients[site.DBName] = client
+	return client
+}
+
+func langKey(lang string, project string) string {
+	project = strings.ToLower(project)
+
+	if alias, ok := registryProjectAliases[project]; ok {
+		project = alias
+	}
+
+	return strings.ToLower(lang) + "|" + project
+}
+
+// rateLimitTransport keeps the minimal interval between requests made through the transport.
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	interval time.Duration
+	mu       sync.Mutex
+	last     time.Time
+}
+
+func newRateLimitTransport(next http.RoundTripper, interval time.Duration) *rateLimitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &rateLimitTransport{
+		next:     next,
+		interval: interval,
+	}
+}
+
+// RoundTrip wait for the turn and execute the request.
+func (rt *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	wait := time.Until(rt.last.Add(rt.interval))
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	rt.last = time.Now().Add(wait)
+	rt.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	return rt.next.RoundTrip(req)
+}
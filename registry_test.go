@@ -0,0 +1,187 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const registryTestSitematrixURL = "/sitematrix"
+const registryTestResponse = `{
+	"sitematrix": {
+		"count": 5,
+		"0": {
+			"code": "en",
+			"name": "English",
+			"site": [
+				{ "url": "https://en.wikipedia.org", "dbname": "enwiki", "code": "wiki", "sitename": "Wikipedia" },
+				{ "url": "https://en.wiktionary.org", "dbname": "enwiktionary", "code": "wiktionary", "sitename": "Wiktionary" }
+			]
+		},
+		"1": {
+			"code": "aa",
+			"name": "Qafár af",
+			"site": [
+				{ "url": "https://aa.wikipedia.org", "dbname": "aawiki", "code": "wiki", "sitename": "Wikipedia", "closed": true }
+			]
+		},
+		"specials": [
+			{ "url": "https://commons.wikimedia.org", "dbname": "commonswiki", "code": "commons", "lang": "commons", "sitename": "Wikimedia Commons" },
+			{ "url": "https://board.wikimedia.org", "dbname": "boardwiki", "code": "board", "lang": "board", "sitename": "Board", "private": true },
+			{ "url": "https://nostalgia.wikipedia.org", "dbname": "nostalgiawiki", "code": "nostalgia", "lang": "nostalgia", "sitename": "Nostalgia", "fishbowl": true }
+		]
+	}
+}`
+
+func createRegistryServer(calls *int) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(registryTestSitematrixURL, func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(registryTestResponse))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestRegistry(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(createRegistryServer(&calls))
+	defer srv.Close()
+
+	source := NewBuilder(srv.URL).
+		Headers(map[string]string{"User-Agent": "test"}).
+		Build()
+	source.options.SitematrixURL = registryTestSitematrixURL
+
+	ctx := context.Background()
+	reg := NewRegistry(source)
+
+	t.Run("lookup", func(t *testing.T) {
+		client, err := reg.Client(ctx, "enwiki")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://en.wikipedia.org", client.url)
+		assert.Equal(t, "test", client.headers["User-Agent"])
+		assert.Equal(t, source.httpClient, client.httpClient)
+
+		same, err := reg.Client(ctx, "en.wikipedia.org")
+		assert.NoError(t, err)
+		assert.True(t, client == same)
+
+		same, err = reg.ClientFor(ctx, "EN", "wikipedia")
+		assert.NoError(t, err)
+		assert.True(t, client == same)
+
+		wiktionary, err := reg.ClientFor(ctx, "en", "wiktionary")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://en.wiktionary.org", wiktionary.url)
+
+		commons, err := reg.ClientFor(ctx, "", "commons")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://commons.wikimedia.org", commons.url)
+
+		site, err := reg.Site(ctx, "https://commons.wikimedia.org/wiki/Main_Page")
+		assert.NoError(t, err)
+		assert.Equal(t, "commonswiki", site.DBName)
+		assert.True(t, site.Special)
+
+		_, err = reg.Client(ctx, "dewiki")
+		assert.True(t, errors.Is(err, ErrSiteNotFound))
+
+		_, err = reg.ClientFor(ctx, "de", "wiki")
+		assert.True(t, errors.Is(err, ErrSiteNotFound))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		sites, err := reg.Sites(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sites, 6)
+		assert.Equal(t, "aawiki", sites[0].DBName)
+
+		sites, err = reg.Sites(ctx, ExcludeClosed, ExcludePrivate, ExcludeFishbowl)
+		assert.NoError(t, err)
+
+		names := []string{}
+
+		for _, site := range sites {
+			names = append(names, site.DBName)
+		}
+
+		assert.Equal(t, []string{"commonswiki", "enwiki", "enwiktionary"}, names)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		calls = 0
+		reg := NewRegistry(source, RegistryOptions{RefreshInterval: time.Millisecond}, RegistryOptions{RateLimit: time.Microsecond})
+
+		_, err := reg.Sites(ctx)
+		assert.NoError(t, err)
+
+		time.Sleep(time.Millisecond * 5)
+
+		_, err = reg.Sites(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("stale on failure", func(t *testing.T) {
+		reg := NewRegistry(source, RegistryOptions{RefreshInterval: time.Millisecond})
+
+		_, err := reg.Client(ctx, "enwiki")
+		assert.NoError(t, err)
+
+		source.options.SitematrixURL = "/missing"
+		defer func() { source.options.SitematrixURL = registryTestSitematrixURL }()
+		time.Sleep(time.Millisecond * 5)
+
+		client, err := reg.Client(ctx, "enwiki")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://en.wikipedia.org", client.url)
+
+		_, err = NewRegistry(source).Client(ctx, "enwiki")
+		assert.Error(t, err)
+	})
+
+	t.Run("background refresh", func(t *testing.T) {
+		reg := NewRegistry(source, RegistryOptions{RefreshInterval: time.Millisecond * 5})
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		reg.StartRefresh(ctx)
+		time.Sleep(time.Millisecond * 50)
+
+		reg.mu.Lock()
+		loaded := reg.loaded
+		reg.mu.Unlock()
+		assert.False(t, loaded.IsZero())
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		reg := NewRegistry(source, RegistryOptions{RateLimit: time.Millisecond * 20})
+		client, err := reg.Client(ctx, "enwiki")
+		assert.NoError(t, err)
+		assert.NotEqual(t, source.httpClient, client.httpClient)
+
+		client.url = srv.URL
+		start := time.Now()
+
+		for i := 0; i < 3; i++ {
+			_, err := client.Sitematrix(ctx)
+			assert.NoError(t, err)
+		}
+
+		assert.True(t, time.Since(start) >= time.Millisecond*40)
+	})
+}
@@ -8,22 +8,27 @@ import (
 )
 
 func req(ctx context.Context, cl *http.Client, method string, url string, reqBody io.Reader, headers ...map[string]string) ([]byte, int, error) {
+	resBody, status, _, err := reqWithHeaders(ctx, cl, method, url, reqBody, headers...)
+	return resBody, status, err
+}
+
+func reqWithHeaders(ctx context.Context, cl *http.Client, method string, url string, reqBody io.Reader, headers ...map[string]string) ([]byte, int, http.Header, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
 	for _, header := range headers {
 		for key, value := range header {
 			req.Header.Add(key, value)
 		}
 	}
 
-	if err != nil {
-		return nil, 0, err
-	}
-
 	res, err := cl.Do(req)
 
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	defer res.Body.Close()
@@ -31,8 +36,8 @@ func req(ctx context.Context, cl *http.Client, method string, url string, reqBod
 	resBody, err := ioutil.ReadAll(res.Body)
 
 	if err != nil {
-		return nil, res.StatusCode, err
+		return nil, res.StatusCode, res.Header, err
 	}
 
-	return resBody, res.StatusCode, nil
+	return resBody, res.StatusCode, res.Header, nil
 }
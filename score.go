@@ -0,0 +1,121 @@
+package mediawiki
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+)
+
+const scoreURL = "https://api.wikimedia.org/service/lw/inference/v1/models"
+
+// Path of ORES v3 scores API, ScoreURL ending with it is queried as ORES.
+const scoreORESPath = "/v3/scores"
+
+// Max number of revisions scored by a single ORES request and Lift Wing requests made at once.
+const (
+	scoreORESRevisionsLimit = 50
+	scoreConcurrency        = 5
+)
+
+// Models supported by Score method.
+const (
+	ScoreModelDamaging       = "damaging"
+	ScoreModelGoodfaith      = "goodfaith"
+	ScoreModelArticleQuality = "articlequality"
+	ScoreModelArticleTopic   = "articletopic"
+	ScoreModelDraftQuality   = "draftquality"
+)
+
+// scoreModels models that have a field in PageDataOresScores.
+var scoreModels = map[string]bool{
+	ScoreModelDamaging:       true,
+	ScoreModelGoodfaith:      true,
+	ScoreModelArticleQuality: true,
+	ScoreModelArticleTopic:   true,
+	ScoreModelDraftQuality:   true,
+}
+
+// ErrUnsupportedModel model scores can't be represented by PageDataOresScores.
+var ErrUnsupportedModel = errors.New("unsupported score model")
+
+type scoreRequest struct {
+	RevID int `json:"rev_id"`
+}
+
+// scoreResponse ORES v3 response format, used by Lift Wing revscoring models as well.
+type scoreResponse map[string]struct {
+	Scores map[string]map[string]struct {
+		Score *struct {
+			Probability json.RawMessage `json:"probability"`
+		} `json:"score"`
+		Error *struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"scores"`
+}
+
+// scores get scores of the revision for the model from the response.
+func (res scoreResponse) scores(wiki string, model string, revid int) (PageDataOresScores, error) {
+	scores := PageDataOresScores{}
+	result, ok := res[wiki].Scores[strconv.Itoa(revid)][model]
+
+	if !ok {
+		return scores, ErrEmptyResult
+	}
+
+	if result.Error != nil {
+		return scores, &APIError{Code: result.Error.Type, Info: result.Error.Message}
+	}
+
+	if result.Score == nil {
+		return scores, ErrEmptyResult
+	}
+
+	err := scores.set(model, result.Score.Probability)
+	return scores, err
+}
+
+// set decode model probabilities into the matching field.
+func (scores *PageDataOresScores) set(model string, probability json.RawMessage) error {
+	switch model {
+	case ScoreModelDamaging:
+		return json.Unmarshal(probability, &scores.Damaging)
+	case ScoreModelGoodfaith:
+		return json.Unmarshal(probability, &scores.Goodfaith)
+	case ScoreModelArticleQuality:
+		return json.Unmarshal(probability, &scores.Articlequality)
+	case ScoreModelArticleTopic:
+		return json.Unmarshal(probability, &scores.Articletopic)
+	case ScoreModelDraftQuality:
+		return json.Unmarshal(probability, &scores.Draftquality)
+	}
+
+	return ErrUnsupportedModel
+}
+
+// oresClass get the class with the highest probability, ties are resolved alphabetically.
+func oresClass(probabilities map[string]float64) string {
+	classes := []string{}
+
+	for class, probability := range probabilities {
+		if probability > 0 {
+			classes = append(classes, class)
+		}
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		if probabilities[classes[i]] == probabilities[classes[j]] {
+			return classes[i] < classes[j]
+		}
+
+		return probabilities[classes[i]] > probabilities[classes[j]]
+	})
+
+	if len(classes) == 0 {
+		return ""
+	}
+
+	return classes[0]
+}
@@ -0,0 +1,129 @@
+package mediawiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const scoreTestURL = "/models"
+const scoreTestORESURL = "/ores/v3/scores"
+const scoreTestSiteInfoURL = "/score-siteinfo"
+const scoreTestRevID = 1000
+const scoreTestErrRevID = 1001
+
+func createScoreServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(scoreTestSiteInfoURL, func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"query": {"general": {"wikiid": "enwiki"}}}`))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	handler := func(model string, score string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body := new(scoreRequest)
+
+			if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(body) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			result := fmt.Sprintf(`{"score": %s}`, score)
+
+			if body.RevID == scoreTestErrRevID {
+				result = `{"error": {"type": "RevisionNotFound", "message": "revision not found"}}`
+			}
+
+			_, err := w.Write([]byte(fmt.Sprintf(`{"enwiki": {"models": {"%s": {"version": "0.5.1"}}, "scores": {"%d": {"%s": %s}}}}`, model, body.RevID, model, result)))
+
+			if err != nil {
+				log.Panic(err)
+			}
+		}
+	}
+
+	router.HandleFunc(scoreTestURL+"/enwiki-damaging:predict", handler(ScoreModelDamaging, `{"prediction": false, "probability": {"false": 0.9, "true": 0.1}}`))
+	router.HandleFunc(scoreTestURL+"/enwiki-articletopic:predict", handler(ScoreModelArticleTopic, `{"prediction": ["STEM.STEM*"], "probability": {"STEM.STEM*": 0.8, "Culture.Biography.Biography*": 0.1}}`))
+	router.HandleFunc(scoreTestURL+"/enwiki-draftquality:predict", handler(ScoreModelDraftQuality, `{"prediction": "OK", "probability": {"OK": 0.7, "attack": 0.1, "spam": 0.1, "vandalism": 0.1}}`))
+
+	router.HandleFunc(scoreTestORESURL+"/enwiki/", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if r.Method != http.MethodGet || query.Get("models") != ScoreModelDamaging || query.Get("revids") != "1000|1002" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"enwiki": {"models": {"damaging": {"version": "0.5.1"}}, "scores": {
+			"1000": {"damaging": {"score": {"prediction": false, "probability": {"false": 0.9, "true": 0.1}}}},
+			"1002": {"damaging": {"score": {"prediction": true, "probability": {"false": 0.2, "true": 0.8}}}}
+		}}}`))
+	})
+
+	return router
+}
+
+func TestScore(t *testing.T) {
+	srv := httptest.NewServer(createScoreServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.SiteInfoURL = scoreTestSiteInfoURL
+	client.options.ScoreURL = srv.URL + scoreTestURL
+	ctx := context.Background()
+
+	t.Run("damaging", func(t *testing.T) {
+		scores, err := client.Score(ctx, ScoreModelDamaging, []int{scoreTestRevID, scoreTestRevID + 2})
+		assert.NoError(t, err)
+		assert.Len(t, scores, 2)
+		assert.Equal(t, 0.1, scores[scoreTestRevID].Damaging.True)
+		assert.Equal(t, 0.9, scores[scoreTestRevID+2].Damaging.False)
+	})
+
+	t.Run("articletopic", func(t *testing.T) {
+		scores, err := client.Score(ctx, ScoreModelArticleTopic, []int{scoreTestRevID})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.8, scores[scoreTestRevID].Articletopic["STEM.STEM*"])
+	})
+
+	t.Run("draftquality", func(t *testing.T) {
+		scores, err := client.Score(ctx, ScoreModelDraftQuality, []int{scoreTestRevID})
+		assert.NoError(t, err)
+		assert.Equal(t, "OK", scores[scoreTestRevID].Draftquality.Class())
+	})
+
+	t.Run("revision error", func(t *testing.T) {
+		_, err := client.Score(ctx, ScoreModelDamaging, []int{scoreTestErrRevID})
+		apiErr := new(APIError)
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "RevisionNotFound", apiErr.Code)
+	})
+
+	t.Run("ores", func(t *testing.T) {
+		client := NewClient(srv.URL)
+		client.options.SiteInfoURL = scoreTestSiteInfoURL
+		client.options.ScoreURL = srv.URL + scoreTestORESURL + "/"
+
+		scores, err := client.Score(ctx, ScoreModelDamaging, []int{scoreTestRevID, scoreTestRevID + 2})
+		assert.NoError(t, err)
+		assert.Len(t, scores, 2)
+		assert.Equal(t, 0.1, scores[scoreTestRevID].Damaging.True)
+		assert.Equal(t, 0.8, scores[scoreTestRevID+2].Damaging.True)
+	})
+
+	t.Run("unsupported model", func(t *testing.T) {
+		_, err := client.Score(ctx, "revertrisk", []int{scoreTestRevID})
+		assert.True(t, errors.Is(err, ErrUnsupportedModel))
+	})
+}
@@ -0,0 +1,300 @@
+package mediawiki
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const siteInfoURL = "/w/api.php"
+
+// Default time to keep site info in the client cache.
+const siteInfoTTL = time.Hour
+
+// Properties of the site info, see https://www.mediawiki.org/wiki/API:Siteinfo for details.
+const (
+	SiteInfoPropGeneral          = "general"
+	SiteInfoPropNamespaces       = "namespaces"
+	SiteInfoPropNamespaceAliases = "namespacealiases"
+	SiteInfoPropStatistics       = "statistics"
+	SiteInfoPropExtensions       = "extensions"
+	SiteInfoPropInterwikiMap     = "interwikimap"
+	SiteInfoPropMagicWords       = "magicwords"
+	SiteInfoPropUserGroups       = "usergroups"
+	SiteInfoPropRestrictions     = "restrictions"
+	SiteInfoPropFileExtensions   = "fileextensions"
+	SiteInfoPropRightsInfo       = "rightsinfo"
+)
+
+// siteInfoProps properties requested when none are specified.
+var siteInfoProps = []string{
+	SiteInfoPropGeneral,
+	SiteInfoPropNamespaces,
+	SiteInfoPropNamespaceAliases,
+	SiteInfoPropStatistics,
+	SiteInfoPropExtensions,
+	SiteInfoPropInterwikiMap,
+	SiteInfoPropMagicWords,
+	SiteInfoPropUserGroups,
+	SiteInfoPropRestrictions,
+	SiteInfoPropFileExtensions,
+	SiteInfoPropRightsInfo,
+}
+
+// SiteInfoLanguage language code and name, used for fallbacks and variants.
+type SiteInfoLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// SiteInfoGeneralInfo overall wiki configuration.
+type SiteInfoGeneralInfo struct {
+	MainPage       string             `json:"mainpage"`
+	Base           string             `json:"base"`
+	SiteName       string             `json:"sitename"`
+	Generator      string             `json:"generator"`
+	PHPVersion     string             `json:"phpversion"`
+	DBType         string             `json:"dbtype"`
+	Case           string             `json:"case"`
+	Lang           string             `json:"lang"`
+	Fallback       []SiteInfoLanguage `json:"fallback"`
+	Variants       []SiteInfoLanguage `json:"variants"`
+	RTL            bool               `json:"rtl"`
+	Timezone       string             `json:"timezone"`
+	TimeOffset     int                `json:"timeoffset"`
+	ArticlePath    string             `json:"articlepath"`
+	ScriptPath     string             `json:"scriptpath"`
+	Script         string             `json:"script"`
+	Server         string             `json:"server"`
+	ServerName     string             `json:"servername"`
+	WikiID         string             `json:"wikiid"`
+	Time           time.Time          `json:"time"`
+	ReadOnly       bool               `json:"readonly"`
+	MaxArticleSize int                `json:"maxarticlesize"`
+	LegalTitles    string             `json:"legaltitlechars"`
+}
+
+// Version get MediaWiki version from the generator string ("MediaWiki 1.41.0-wmf.5" becomes "1.41.0-wmf.5").
+func (gen *SiteInfoGeneralInfo) Version() string {
+	return strings.TrimSpace(strings.TrimPrefix(gen.Generator, "MediaWiki"))
+}
+
+// SiteInfoStatistics wiki statistics.
+type SiteInfoStatistics struct {
+	Pages       int `json:"pages"`
+	Articles    int `json:"articles"`
+	Edits       int `json:"edits"`
+	Images      int `json:"images"`
+	Users       int `json:"users"`
+	ActiveUsers int `json:"activeusers"`
+	Admins      int `json:"admins"`
+	Jobs        int `json:"jobs"`
+}
+
+// SiteInfoExtension installed extension or skin.
+type SiteInfoExtension struct {
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+	NameMsg        string `json:"namemsg"`
+	DescriptionMsg string `json:"descriptionmsg"`
+	Author         string `json:"author"`
+	URL            string `json:"url"`
+	Version        string `json:"version"`
+	VCSSystem      string `json:"vcs-system"`
+	VCSVersion     string `json:"vcs-version"`
+	VCSURL         string `json:"vcs-url"`
+	LicenseName    string `json:"license-name"`
+}
+
+// SiteInfoInterwiki single interwiki prefix.
+type SiteInfoInterwiki struct {
+	Prefix   string `json:"prefix"`
+	Local    bool   `json:"local"`
+	Language string `json:"language"`
+	URL      string `json:"url"`
+	ProtoRel bool   `json:"protorel"`
+	WikiID   string `json:"wikiid"`
+	API      string `json:"api"`
+}
+
+// SiteInfoMagicWord magic word with it's localized aliases.
+type SiteInfoMagicWord struct {
+	Name          string   `json:"name"`
+	Aliases       []string `json:"aliases"`
+	CaseSensitive bool     `json:"case-sensitive"`
+}
+
+// SiteInfoUserGroup user group with it's rights.
+type SiteInfoUserGroup struct {
+	Name   string   `json:"name"`
+	Rights []string `json:"rights"`
+}
+
+// SiteInfoRestrictions page protection settings.
+type SiteInfoRestrictions struct {
+	Types               []string `json:"types"`
+	Levels              []string `json:"levels"`
+	CascadingLevels     []string `json:"cascadinglevels"`
+	SemiProtectedLevels []string `json:"semiprotectedlevels"`
+}
+
+// SiteInfoRightsInfo wiki license.
+type SiteInfoRightsInfo struct {
+	URL  string `json:"url"`
+	Text string `json:"text"`
+}
+
+// SiteInfo wiki configuration, only requested properties are filled.
+type SiteInfo struct {
+	General          SiteInfoGeneralInfo
+	Namespaces       []Namespace
+	NamespaceAliases []NamespaceAlias
+	Statistics       SiteInfoStatistics
+	Extensions       []SiteInfoExtension
+	InterwikiMap     []SiteInfoInterwiki
+	MagicWords       []SiteInfoMagicWord
+	UserGroups       []SiteInfoUserGroup
+	Restrictions     SiteInfoRestrictions
+	FileExtensions   []string
+	RightsInfo       SiteInfoRightsInfo
+}
+
+// Titles get title parser from site namespaces and aliases.
+func (si *SiteInfo) Titles() *Titles {
+	return NewTitles(si.Namespaces, si.NamespaceAliases...)
+}
+
+// Extension find installed extension by name.
+func (si *SiteInfo) Extension(name string) (SiteInfoExtension, bool) {
+	for _, ext := range si.Extensions {
+		if ext.Name == name {
+			return ext, true
+		}
+	}
+
+	return SiteInfoExtension{}, false
+}
+
+// UserGroup find user group by name.
+func (si *SiteInfo) UserGroup(name string) (SiteInfoUserGroup, bool) {
+	for _, group := range si.UserGroups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+
+	return SiteInfoUserGroup{}, false
+}
+
+type siteInfoResponse struct {
+	Query struct {
+		General          SiteInfoGeneralInfo  `json:"general"`
+		Namespaces       map[int]Namespace    `json:"namespaces"`
+		NamespaceAliases []NamespaceAlias     `json:"namespacealiases"`
+		Statistics       SiteInfoStatistics   `json:"statistics"`
+		Extensions       []SiteInfoExtension  `json:"extensions"`
+		InterwikiMap     []SiteInfoInterwiki  `json:"interwikimap"`
+		MagicWords       []SiteInfoMagicWord  `json:"magicwords"`
+		UserGroups       []SiteInfoUserGroup  `json:"usergroups"`
+		Restrictions     SiteInfoRestrictions `json:"restrictions"`
+		FileExtensions   []struct {
+			Ext string `json:"ext"`
+		} `json:"fileextensions"`
+		RightsInfo SiteInfoRightsInfo `json:"rightsinfo"`
+	} `json:"query"`
+}
+
+func (res *siteInfoResponse) siteInfo() *SiteInfo {
+	info := &SiteInfo{
+		General:          res.Query.General,
+		NamespaceAliases: res.Query.NamespaceAliases,
+		Statistics:       res.Query.Statistics,
+		Extensions:       res.Query.Extensions,
+		InterwikiMap:     res.Query.InterwikiMap,
+		MagicWords:       res.Query.MagicWords,
+		UserGroups:       res.Query.UserGroups,
+		Restrictions:     res.Query.Restrictions,
+		RightsInfo:       res.Query.RightsInfo,
+	}
+
+	for _, ns := range res.Query.Namespaces {
+		info.Namespaces = append(info.Namespaces, ns)
+	}
+
+	sort.Slice(info.Namespaces, func(i, j int) bool {
+		return info.Namespaces[i].ID < info.Namespaces[j].ID
+	})
+
+	for _, ext := range res.Query.FileExtensions {
+		info.FileExtensions = append(info.FileExtensions, ext.Ext)
+	}
+
+	return info
+}
+
+// clone deep copy of the site info, so cached value can't be changed by the callers.
+func (si *SiteInfo) clone() *SiteInfo {
+	info := *si
+	info.General.Fallback = append([]SiteInfoLanguage(nil), si.General.Fallback...)
+	info.General.Variants = append([]SiteInfoLanguage(nil), si.General.Variants...)
+	info.Namespaces = append([]Namespace(nil), si.Namespaces...)
+	info.NamespaceAliases = append([]NamespaceAlias(nil), si.NamespaceAliases...)
+	info.Extensions = append([]SiteInfoExtension(nil), si.Extensions...)
+	info.InterwikiMap = append([]SiteInfoInterwiki(nil), si.InterwikiMap...)
+	info.MagicWords = append([]SiteInfoMagicWord(nil), si.MagicWords...)
+	info.UserGroups = append([]SiteInfoUserGroup(nil), si.UserGroups...)
+	info.Restrictions.Types = append([]string(nil), si.Restrictions.Types...)
+	info.Restrictions.Levels = append([]string(nil), si.Restrictions.Levels...)
+	info.Restrictions.CascadingLevels = append([]string(nil), si.Restrictions.CascadingLevels...)
+	info.Restrictions.SemiProtectedLevels = append([]string(nil), si.Restrictions.SemiProtectedLevels...)
+	info.FileExtensions = append([]string(nil), si.FileExtensions...)
+
+	for i := range info.MagicWords {
+		info.MagicWords[i].Aliases = append([]string(nil), info.MagicWords[i].Aliases...)
+	}
+
+	for i := range info.UserGroups {
+		info.UserGroups[i].Rights = append([]string(nil), info.UserGroups[i].Rights...)
+	}
+
+	return &info
+}
+
+type siteInfoCacheEntry struct {
+	info    *SiteInfo
+	expires time.Time
+}
+
+// siteInfoCache site info responses keyed by the requested properties, callers get their own copy of the cached value.
+type siteInfoCache struct {
+	mu      sync.Mutex
+	entries map[string]siteInfoCacheEntry
+}
+
+func (sc *siteInfoCache) get(key string) (*SiteInfo, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[key]
+
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.info.clone(), true
+}
+
+func (sc *siteInfoCache) set(key string, info *SiteInfo, ttl time.Duration) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.entries == nil {
+		sc.entries = map[string]siteInfoCacheEntry{}
+	}
+
+	sc.entries[key] = siteInfoCacheEntry{
+		info:    info.clone(),
+		expires: time.Now().Add(ttl),
+	}
+}
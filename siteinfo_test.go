@@ -0,0 +1,141 @@
+package mediawiki
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const siteInfoTestURL = "/siteinfo"
+const siteInfoTestResponse = `{
+	"batchcomplete": true,
+	"query": {
+		"general": {
+			"mainpage": "Main Page",
+			"base": "https://zh.wikipedia.org/wiki/Main_Page",
+			"sitename": "Wikipedia",
+			"generator": "MediaWiki 1.41.0-wmf.5",
+			"case": "first-letter",
+			"lang": "zh",
+			"fallback": [{ "code": "zh-hans" }],
+			"variants": [{ "code": "zh", "name": "zh" }, { "code": "zh-hans", "name": "zh-hans" }],
+			"timezone": "Asia/Shanghai",
+			"timeoffset": 480,
+			"articlepath": "/wiki/$1",
+			"scriptpath": "/w",
+			"server": "//zh.wikipedia.org",
+			"wikiid": "zhwiki",
+			"time": "2023-04-01T10:00:00Z"
+		},
+		"namespaces": {
+			"1": { "id": 1, "case": "first-letter", "name": "Talk", "canonical": "Talk" },
+			"0": { "id": 0, "case": "first-letter", "name": "" }
+		},
+		"namespacealiases": [{ "id": 1, "alias": "讨论" }],
+		"statistics": { "pages": 10, "articles": 5, "edits": 100, "images": 2, "users": 3, "activeusers": 1, "admins": 1, "jobs": 0 },
+		"extensions": [{ "type": "parserhook", "name": "Cite", "version": "1.0.0" }],
+		"interwikimap": [{ "prefix": "en", "local": true, "language": "English", "url": "https://en.wikipedia.org/wiki/$1" }],
+		"magicwords": [{ "name": "redirect", "aliases": ["#REDIRECT", "#重定向"], "case-sensitive": false }],
+		"usergroups": [{ "name": "sysop", "rights": ["block", "delete"] }],
+		"restrictions": { "types": ["edit", "move"], "levels": ["", "autoconfirmed", "sysop"], "cascadinglevels": ["sysop"], "semiprotectedlevels": ["autoconfirmed"] },
+		"fileextensions": [{ "ext": "png" }, { "ext": "svg" }],
+		"rightsinfo": { "url": "https://creativecommons.org/licenses/by-sa/4.0/", "text": "CC BY-SA 4.0" }
+	}
+}`
+
+func createSiteInfoServer(calls *int) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(siteInfoTestURL, func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		if r.URL.Query().Get("meta") != "siteinfo" || len(r.URL.Query().Get("siprop")) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(siteInfoTestResponse))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestSiteInfo(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(createSiteInfoServer(&calls))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.SiteInfoURL = siteInfoTestURL
+
+	info, err := client.SiteInfo(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Main Page", info.General.MainPage)
+	assert.Equal(t, "1.41.0-wmf.5", info.General.Version())
+	assert.Equal(t, NamespaceCaseFirstLetter, info.General.Case)
+	assert.Equal(t, "Asia/Shanghai", info.General.Timezone)
+	assert.Equal(t, "/wiki/$1", info.General.ArticlePath)
+	assert.Equal(t, "/w", info.General.ScriptPath)
+	assert.Equal(t, "zh", info.General.Lang)
+	assert.Len(t, info.General.Variants, 2)
+	assert.Equal(t, time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC), info.General.Time)
+
+	assert.Len(t, info.Namespaces, 2)
+	assert.Equal(t, 0, info.Namespaces[0].ID)
+	assert.Equal(t, []NamespaceAlias{{ID: 1, Alias: "讨论"}}, info.NamespaceAliases)
+	assert.Equal(t, 100, info.Statistics.Edits)
+	assert.Equal(t, "en", info.InterwikiMap[0].Prefix)
+	assert.Equal(t, []string{"#REDIRECT", "#重定向"}, info.MagicWords[0].Aliases)
+	assert.Equal(t, []string{"sysop"}, info.Restrictions.CascadingLevels)
+	assert.Equal(t, []string{"png", "svg"}, info.FileExtensions)
+	assert.Equal(t, "CC BY-SA 4.0", info.RightsInfo.Text)
+
+	ext, ok := info.Extension("Cite")
+	assert.True(t, ok)
+	assert.Equal(t, "1.0.0", ext.Version)
+
+	group, ok := info.UserGroup("sysop")
+	assert.True(t, ok)
+	assert.Contains(t, group.Rights, "delete")
+
+	title, err := info.Titles().Parse("讨论:foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Talk:Foo", title.String())
+
+	info.Extensions[0].Version = "changed"
+	info.MagicWords[0].Aliases[0] = "changed"
+	info.Namespaces = nil
+
+	cached, err := client.SiteInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", cached.Extensions[0].Version)
+	assert.Equal(t, "#REDIRECT", cached.MagicWords[0].Aliases[0])
+	assert.Len(t, cached.Namespaces, 2)
+	assert.Equal(t, 1, calls)
+
+	_, err = client.SiteInfo(context.Background(), SiteInfoPropStatistics, SiteInfoPropGeneral)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = client.SiteInfo(context.Background(), SiteInfoPropGeneral, SiteInfoPropStatistics, SiteInfoPropGeneral)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	client.siteInfoTTL = 0
+	_, err = client.SiteInfo(context.Background(), SiteInfoPropRightsInfo)
+	assert.NoError(t, err)
+	_, err = client.SiteInfo(context.Background(), SiteInfoPropRightsInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,151 @@
+// Package sparql client for Wikidata Query Service and other endpoints returning SPARQL JSON results.
+package sparql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultEndpoint Wikidata Query Service endpoint.
+const DefaultEndpoint = "https://query.wikidata.org/sparql"
+
+const resultsContentType = "application/sparql-results+json"
+
+// Server side timeout is reported by Blazegraph as java exception in the response body.
+const timeoutException = "java.util.concurrent.TimeoutException"
+
+const errBadRequestMsg = "status: '%d' body: '%s'"
+
+// TimeoutError query took longer than allowed either by the server or by the context.
+type TimeoutError struct {
+	Query string
+	Err   error
+}
+
+// Error get error message.
+func (err *TimeoutError) Error() string {
+	return fmt.Sprintf("sparql query timeout: %v", err.Err)
+}
+
+// Unwrap get the underlying error.
+func (err *TimeoutError) Unwrap() error {
+	return err.Err
+}
+
+// NewClient create new client for the endpoint, headers (like User-Agent) are sent with every query.
+func NewClient(endpoint string, httpClient *http.Client, headers map[string]string) *Client {
+	if httpClient == nil {
+		httpClient = new(http.Client)
+	}
+
+	return &Client{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		headers:    headers,
+	}
+}
+
+// Client SPARQL endpoint client.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	headers    map[string]string
+}
+
+// Query run the query and decode all the results.
+func (cl *Client) Query(ctx context.Context, query string) (*Results, error) {
+	stm, err := cl.Stream(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer stm.Close()
+	res := &Results{
+		Bindings: []Binding{},
+	}
+
+	for stm.Next() {
+		res.Bindings = append(res.Bindings, stm.Binding())
+	}
+
+	if err := stm.Err(); err != nil {
+		return nil, err
+	}
+
+	res.Vars, res.Boolean = stm.Vars(), stm.Boolean()
+	return res, nil
+}
+
+// Stream run the query and decode results one by one, the stream has to be closed by the caller.
+func (cl *Client) Stream(ctx context.Context, query string) (*Stream, error) {
+	body := url.Values{
+		"query": []string{query},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cl.endpoint, strings.NewReader(body.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range cl.headers {
+		req.Header.Set(key, value)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", resultsContentType)
+
+	res, err := cl.httpClient.Do(req)
+
+	if err != nil {
+		return nil, wrapTimeout(query, err, "")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		data, _ := ioutil.ReadAll(res.Body)
+
+		if strings.Contains(string(data), timeoutException) {
+			return nil, &TimeoutError{
+				Query: query,
+				Err:   fmt.Errorf(errBadRequestMsg, res.StatusCode, data),
+			}
+		}
+
+		return nil, fmt.Errorf(errBadRequestMsg, res.StatusCode, data)
+	}
+
+	stm, err := newStream(res.Body, query)
+
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+
+	return stm, nil
+}
+
+// wrapTimeout turn context deadline, network timeouts and server timeout found in the body into TimeoutError.
+func wrapTimeout(query string, err error, body string) error {
+	var netErr net.Error
+
+	if errors.As(err, new(*TimeoutError)) {
+		return err
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) || strings.Contains(body, timeoutException) {
+		return &TimeoutError{
+			Query: query,
+			Err:   err,
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,153 @@
+package sparql
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const clientTestURL = "/sparql"
+const clientTestUserAgent = "test-agent"
+const clientTestResponse = `{
+	"head": {"vars": ["item", "itemLabel"]},
+	"results": {"bindings": [
+		{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q42"}, "itemLabel": {"type": "literal", "value": "Douglas Adams", "xml:lang": "en"}},
+		{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q5"}}
+	]}
+}`
+
+func createClientServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(clientTestURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("User-Agent") != clientTestUserAgent || r.Header.Get("Accept") != resultsContentType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := clientTestResponse
+
+		switch r.FormValue("query") {
+		case "timeout":
+			w.WriteHeader(http.StatusInternalServerError)
+			res = "SPARQL-QUERY: queryStr=timeout\njava.util.concurrent.TimeoutException\n\tat java.util.concurrent.FutureTask.get"
+		case "partial":
+			res = `{"head": {"vars": ["item"]}, "results": {"bindings": [{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q42"}},` +
+				"\nSPARQL-QUERY: queryStr=partial\njava.util.concurrent.TimeoutException\n\tat java.util.concurrent.FutureTask.get"
+		case "slow-stream":
+			_, _ = w.Write([]byte(`{"head": {"vars": ["item"]}, "results": {"bindings": [`))
+			w.(http.Flusher).Flush()
+			time.Sleep(time.Millisecond * 100)
+		case "slow":
+			time.Sleep(time.Millisecond * 100)
+		case "broken":
+			w.WriteHeader(http.StatusBadRequest)
+			res = "MalformedQueryException"
+		}
+
+		_, err := w.Write([]byte(res))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(createClientServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL+clientTestURL, nil, map[string]string{
+		"User-Agent": clientTestUserAgent,
+	})
+	ctx := context.Background()
+
+	t.Run("query", func(t *testing.T) {
+		res, err := client.Query(ctx, "SELECT ?item ?itemLabel WHERE { ?item wdt:P31 wd:Q5 }")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"item", "itemLabel"}, res.Vars)
+		assert.Len(t, res.Bindings, 2)
+		assert.Equal(t, []string{"Q42", "Q5"}, res.QIDs("item"))
+		assert.Equal(t, "Douglas Adams", res.Bindings[0]["itemLabel"].Value)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stm, err := client.Stream(ctx, "SELECT ?item WHERE {}")
+		assert.NoError(t, err)
+		defer stm.Close()
+
+		count := 0
+
+		for stm.Next() {
+			count++
+		}
+
+		assert.NoError(t, stm.Err())
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("server timeout", func(t *testing.T) {
+		_, err := client.Query(ctx, "timeout")
+		timeout := new(TimeoutError)
+		assert.True(t, errors.As(err, &timeout))
+		assert.Equal(t, "timeout", timeout.Query)
+	})
+
+	t.Run("server timeout mid-stream", func(t *testing.T) {
+		_, err := client.Query(ctx, "partial")
+		timeout := new(TimeoutError)
+		assert.True(t, errors.As(err, &timeout))
+		assert.Equal(t, "partial", timeout.Query)
+
+		stm, err := client.Stream(ctx, "partial")
+		assert.NoError(t, err)
+		defer stm.Close()
+
+		count := 0
+
+		for stm.Next() {
+			count++
+		}
+
+		assert.Equal(t, 1, count)
+		assert.True(t, errors.As(stm.Err(), &timeout))
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+		defer cancel()
+
+		_, err := client.Query(ctx, "slow")
+		timeout := new(TimeoutError)
+		assert.True(t, errors.As(err, &timeout))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("context timeout mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+		defer cancel()
+
+		stm, err := client.Stream(ctx, "slow-stream")
+		assert.NoError(t, err)
+		defer stm.Close()
+
+		assert.False(t, stm.Next())
+		timeout := new(TimeoutError)
+		assert.True(t, errors.As(stm.Err(), &timeout))
+		assert.True(t, errors.Is(stm.Err(), context.DeadlineExceeded))
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		_, err := client.Query(ctx, "broken")
+		assert.Error(t, err)
+		assert.False(t, errors.As(err, new(*TimeoutError)))
+	})
+}
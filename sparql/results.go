@@ -0,0 +1,50 @@
+package sparql
+
+// Binding values of the variables in a single solution, unbound variables are absent.
+type Binding map[string]Term
+
+// QID get entity id bound to the variable.
+func (bnd Binding) QID(variable string) (string, bool) {
+	term, ok := bnd[variable]
+
+	if !ok {
+		return "", false
+	}
+
+	return term.QID()
+}
+
+// Results query results, Boolean is set only for ASK queries.
+type Results struct {
+	Vars     []string
+	Bindings []Binding
+	Boolean  *bool
+}
+
+// QIDs get unique entity ids bound to the variable in the order of appearance.
+func (res *Results) QIDs(variable string) []string {
+	ids := []string{}
+	seen := map[string]bool{}
+
+	for _, bnd := range res.Bindings {
+		if id, ok := bnd.QID(variable); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// Values get values bound to the variable, unbound solutions are skipped.
+func (res *Results) Values(variable string) []Term {
+	terms := []Term{}
+
+	for _, bnd := range res.Bindings {
+		if term, ok := bnd[variable]; ok {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
@@ -0,0 +1,30 @@
+package sparql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResults(t *testing.T) {
+	res := &Results{
+		Vars: []string{"item", "label"},
+		Bindings: []Binding{
+			{"item": {Type: TypeURI, Value: "http://www.wikidata.org/entity/Q1"}, "label": {Type: TypeLiteral, Value: "one", Lang: "en"}},
+			{"item": {Type: TypeURI, Value: "http://www.wikidata.org/entity/Q2"}},
+			{"item": {Type: TypeURI, Value: "http://www.wikidata.org/entity/Q1"}},
+			{"item": {Type: TypeBNode, Value: "b0"}},
+		},
+	}
+
+	assert.Equal(t, []string{"Q1", "Q2"}, res.QIDs("item"))
+	assert.Len(t, res.Values("label"), 1)
+	assert.Equal(t, "en", res.Values("label")[0].Lang)
+
+	id, ok := res.Bindings[1].QID("item")
+	assert.True(t, ok)
+	assert.Equal(t, "Q2", id)
+
+	_, ok = res.Bindings[1].QID("label")
+	assert.False(t, ok)
+}
@@ -0,0 +1,246 @@
+package sparql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// ErrUnexpectedToken response is not a valid SPARQL JSON result.
+var ErrUnexpectedToken = errors.New("unexpected token in sparql results")
+
+// Max size of the body read after the decoding error to look for the server timeout.
+const streamTrailerLimit = 64 * 1024
+
+// Stream decodes bindings one by one without keeping the whole result in memory.
+// Server timeout appended to the partially sent results is reported as TimeoutError.
+//
+//	for stream.Next() {
+//		fmt.Println(stream.Binding())
+//	}
+//
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+type Stream struct {
+	query   string
+	body    io.ReadCloser
+	dec     *json.Decoder
+	vars    []string
+	boolean *bool
+	binding Binding
+	inArray bool
+	done    bool
+	err     error
+}
+
+type head struct {
+	Vars []string `json:"vars"`
+}
+
+// NewStream start decoding SPARQL JSON results from the reader, decoding stops at the start of bindings.
+func NewStream(body io.ReadCloser) (*Stream, error) {
+	return newStream(body, "")
+}
+
+// newStream start decoding results of the query.
+func newStream(body io.ReadCloser, query string) (*Stream, error) {
+	stm := &Stream{
+		query: query,
+		body:  body,
+		dec:   json.NewDecoder(body),
+	}
+
+	if err := stm.expect(json.Delim('{')); err != nil {
+		return nil, stm.wrap(err)
+	}
+
+	if err := stm.seek(); err != nil {
+		return nil, stm.wrap(err)
+	}
+
+	return stm, nil
+}
+
+// Vars get names of the variables from the result head, available only if head goes before the bindings.
+func (stm *Stream) Vars() []string {
+	return stm.vars
+}
+
+// Boolean get result of ASK query, available after all the bindings are read.
+func (stm *Stream) Boolean() *bool {
+	return stm.boolean
+}
+
+// Next decode next binding, false is returned at the end of results or on error.
+func (stm *Stream) Next() bool {
+	if stm.done || stm.err != nil {
+		return false
+	}
+
+	if stm.inArray && stm.dec.More() {
+		stm.binding = Binding{}
+
+		if err := stm.dec.Decode(&stm.binding); err != nil {
+			stm.err = stm.wrap(err)
+			return false
+		}
+
+		return true
+	}
+
+	if stm.inArray {
+		// closing "]" of bindings and "}" of results
+		if err := stm.expect(json.Delim(']')); err != nil {
+			stm.err = stm.wrap(err)
+			return false
+		}
+
+		stm.inArray = false
+
+		if err := stm.skipObject(); err != nil {
+			stm.err = stm.wrap(err)
+			return false
+		}
+
+		if err := stm.seek(); err != nil {
+			stm.err = stm.wrap(err)
+			return false
+		}
+
+		return stm.Next()
+	}
+
+	stm.done = true
+	return false
+}
+
+// Binding get current binding.
+func (stm *Stream) Binding() Binding {
+	return stm.binding
+}
+
+// Err get decoding error, nil when results were read to the end.
+func (stm *Stream) Err() error {
+	return stm.err
+}
+
+// Close close the underlying response body.
+func (stm *Stream) Close() error {
+	return stm.body.Close()
+}
+
+// wrap turn the decoding error into TimeoutError if the rest of the body holds the server timeout
+// or the body was cut by the context deadline or network timeout.
+func (stm *Stream) wrap(err error) error {
+	data, _ := ioutil.ReadAll(io.LimitReader(io.MultiReader(stm.dec.Buffered(), stm.body), streamTrailerLimit))
+	return wrapTimeout(stm.query, err, string(data))
+}
+
+// seek read top level keys until the start of bindings array or the end of the object.
+func (stm *Stream) seek() error {
+	for stm.dec.More() {
+		tok, err := stm.dec.Token()
+
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "head":
+			hdr := new(head)
+
+			if err := stm.dec.Decode(hdr); err != nil {
+				return err
+			}
+
+			stm.vars = hdr.Vars
+		case "boolean":
+			value := false
+
+			if err := stm.dec.Decode(&value); err != nil {
+				return err
+			}
+
+			stm.boolean = &value
+		case "results":
+			if err := stm.expect(json.Delim('{')); err != nil {
+				return err
+			}
+
+			found, err := stm.seekBindings()
+
+			if err != nil || found {
+				return err
+			}
+		default:
+			if err := stm.skipValue(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return stm.expect(json.Delim('}'))
+}
+
+// seekBindings find "bindings" key inside of "results" object.
+func (stm *Stream) seekBindings() (bool, error) {
+	for stm.dec.More() {
+		tok, err := stm.dec.Token()
+
+		if err != nil {
+			return false, err
+		}
+
+		if tok == "bindings" {
+			if err := stm.expect(json.Delim('[')); err != nil {
+				return false, err
+			}
+
+			stm.inArray = true
+			return true, nil
+		}
+
+		if err := stm.skipValue(); err != nil {
+			return false, err
+		}
+	}
+
+	return false, stm.expect(json.Delim('}'))
+}
+
+// skipObject skip the rest of the current object.
+func (stm *Stream) skipObject() error {
+	for stm.dec.More() {
+		if _, err := stm.dec.Token(); err != nil {
+			return err
+		}
+
+		if err := stm.skipValue(); err != nil {
+			return err
+		}
+	}
+
+	return stm.expect(json.Delim('}'))
+}
+
+func (stm *Stream) skipValue() error {
+	value := json.RawMessage{}
+	return stm.dec.Decode(&value)
+}
+
+func (stm *Stream) expect(delim json.Delim) error {
+	tok, err := stm.dec.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("%w: expected '%s' got '%v'", ErrUnexpectedToken, delim, tok)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package sparql
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStream(data string) (*Stream, error) {
+	return NewStream(ioutil.NopCloser(strings.NewReader(data)))
+}
+
+func TestStream(t *testing.T) {
+	t.Run("bindings", func(t *testing.T) {
+		stm, err := newTestStream(`{
+			"head": {"vars": ["item", "label"]},
+			"results": {"bindings": [
+				{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q42"}, "label": {"type": "literal", "value": "Douglas Adams", "xml:lang": "en"}},
+				{"item": {"type": "bnode", "value": "t1"}, "label": {"type": "literal", "value": "42", "datatype": "http://www.w3.org/2001/XMLSchema#integer"}}
+			]}
+		}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"item", "label"}, stm.Vars())
+
+		bindings := []Binding{}
+
+		for stm.Next() {
+			bindings = append(bindings, stm.Binding())
+		}
+
+		assert.NoError(t, stm.Err())
+		assert.Nil(t, stm.Boolean())
+		assert.NoError(t, stm.Close())
+		assert.Len(t, bindings, 2)
+		assert.Equal(t, "en", bindings[0]["label"].Lang)
+		assert.True(t, bindings[1]["item"].IsBNode())
+		assert.Equal(t, XSDInteger, bindings[1]["label"].Datatype)
+		assert.False(t, stm.Next())
+	})
+
+	t.Run("results before head", func(t *testing.T) {
+		stm, err := newTestStream(`{"results": {"distinct": false, "bindings": [{"x": {"type": "uri", "value": "http://a"}}], "ordered": true}, "head": {"vars": ["x"]}}`)
+		assert.NoError(t, err)
+		assert.Nil(t, stm.Vars())
+		assert.True(t, stm.Next())
+		assert.False(t, stm.Next())
+		assert.NoError(t, stm.Err())
+		assert.Equal(t, []string{"x"}, stm.Vars())
+	})
+
+	t.Run("ask", func(t *testing.T) {
+		stm, err := newTestStream(`{"head": {}, "boolean": true}`)
+		assert.NoError(t, err)
+		assert.False(t, stm.Next())
+		assert.NoError(t, stm.Err())
+		assert.True(t, *stm.Boolean())
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		stm, err := newTestStream(`{"head": {"vars": ["x"]}, "results": {"bindings": [{"x": {"type": "uri", "value": "http://a"}}, {"x": `)
+		assert.NoError(t, err)
+		assert.True(t, stm.Next())
+		assert.False(t, stm.Next())
+		assert.Error(t, stm.Err())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newTestStream(`[]`)
+		assert.True(t, errors.Is(err, ErrUnexpectedToken))
+	})
+}
@@ -0,0 +1,99 @@
+package sparql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of RDF terms.
+const (
+	TypeURI          = "uri"
+	TypeLiteral      = "literal"
+	TypeTypedLiteral = "typed-literal"
+	TypeBNode        = "bnode"
+)
+
+// Common XSD datatypes.
+const (
+	XSDInteger  = "http://www.w3.org/2001/XMLSchema#integer"
+	XSDDecimal  = "http://www.w3.org/2001/XMLSchema#decimal"
+	XSDDouble   = "http://www.w3.org/2001/XMLSchema#double"
+	XSDBoolean  = "http://www.w3.org/2001/XMLSchema#boolean"
+	XSDDateTime = "http://www.w3.org/2001/XMLSchema#dateTime"
+)
+
+var qidRx = regexp.MustCompile(`^[QPL][1-9][0-9]*(-[FS][1-9][0-9]*)?$`)
+
+// Term single RDF term bound to a variable.
+type Term struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Lang     string `json:"xml:lang,omitempty"`
+	Datatype string `json:"datatype,omitempty"`
+}
+
+// IsURI check if term is an URI.
+func (trm Term) IsURI() bool {
+	return trm.Type == TypeURI
+}
+
+// IsLiteral check if term is a literal, typed or not.
+func (trm Term) IsLiteral() bool {
+	return trm.Type == TypeLiteral || trm.Type == TypeTypedLiteral
+}
+
+// IsBNode check if term is a blank node.
+func (trm Term) IsBNode() bool {
+	return trm.Type == TypeBNode
+}
+
+// QID get entity id ("Q42", "P31", "L7-F1") of the URI term.
+func (trm Term) QID() (string, bool) {
+	if !trm.IsURI() {
+		return "", false
+	}
+
+	return QID(trm.Value)
+}
+
+// Int parse literal value as integer.
+func (trm Term) Int() (int64, error) {
+	return strconv.ParseInt(trm.Value, 10, 64)
+}
+
+// Float parse literal value as floating point number.
+func (trm Term) Float() (float64, error) {
+	return strconv.ParseFloat(trm.Value, 64)
+}
+
+// Bool parse literal value as boolean.
+func (trm Term) Bool() (bool, error) {
+	return strconv.ParseBool(trm.Value)
+}
+
+// Time parse literal value as date and time.
+func (trm Term) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, trm.Value)
+}
+
+// String get term value.
+func (trm Term) String() string {
+	return trm.Value
+}
+
+// QID get entity id from the entity URI ("http://www.wikidata.org/entity/Q42" becomes "Q42").
+func QID(uri string) (string, bool) {
+	id := uri
+
+	if idx := strings.LastIndexByte(uri, '/'); idx != -1 {
+		id = uri[idx+1:]
+	}
+
+	if !qidRx.MatchString(id) {
+		return "", false
+	}
+
+	return id, true
+}
@@ -0,0 +1,65 @@
+package sparql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerm(t *testing.T) {
+	assert := assert.New(t)
+
+	uri := Term{Type: TypeURI, Value: "http://www.wikidata.org/entity/Q42"}
+	assert.True(uri.IsURI())
+	assert.False(uri.IsLiteral())
+
+	id, ok := uri.QID()
+	assert.True(ok)
+	assert.Equal("Q42", id)
+
+	literal := Term{Type: TypeLiteral, Value: "Q42"}
+	_, ok = literal.QID()
+	assert.False(ok)
+
+	num := Term{Type: TypeLiteral, Value: "1952", Datatype: XSDInteger}
+	value, err := num.Int()
+	assert.NoError(err)
+	assert.Equal(int64(1952), value)
+
+	float := Term{Type: TypeTypedLiteral, Value: "1.96", Datatype: XSDDecimal}
+	assert.True(float.IsLiteral())
+	fvalue, err := float.Float()
+	assert.NoError(err)
+	assert.Equal(1.96, fvalue)
+
+	date := Term{Type: TypeLiteral, Value: "1952-03-11T00:00:00Z", Datatype: XSDDateTime}
+	tvalue, err := date.Time()
+	assert.NoError(err)
+	assert.Equal(time.Date(1952, 3, 11, 0, 0, 0, 0, time.UTC), tvalue)
+
+	flag := Term{Type: TypeLiteral, Value: "true", Datatype: XSDBoolean}
+	bvalue, err := flag.Bool()
+	assert.NoError(err)
+	assert.True(bvalue)
+
+	bnode := Term{Type: TypeBNode, Value: "t123"}
+	assert.True(bnode.IsBNode())
+	assert.Equal("t123", bnode.String())
+}
+
+func TestQID(t *testing.T) {
+	for uri, expected := range map[string]string{
+		"http://www.wikidata.org/entity/Q42":               "Q42",
+		"http://www.wikidata.org/prop/direct/P31":          "P31",
+		"http://www.wikidata.org/entity/L7-F1":             "L7-F1",
+		"https://www.wikidata.org/wiki/Special:Q42":        "",
+		"http://www.wikidata.org/entity/Q0":                "",
+		"http://www.wikidata.org/entity/statement/Q42-abc": "",
+		"Q5": "Q5",
+	} {
+		id, ok := QID(uri)
+		assert.Equal(t, expected, id, uri)
+		assert.Equal(t, len(expected) > 0, ok, uri)
+	}
+}
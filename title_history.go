@@ -0,0 +1,100 @@
+package mediawiki
+
+import (
+	"net/url"
+	"time"
+)
+
+const titleHistoryURL = "/w/api.php"
+
+// Max number of moves followed for a single title.
+const titleHistoryMovesLimit = 100
+
+// Max number of move log queries made at once and titles resolved by a single query in bulk mode.
+const (
+	titleHistoryConcurrency = 10
+	titleHistoryPagesLimit  = 50
+)
+
+// TitleHistoryOptions optional parameters for ResolveTitleHistory method.
+// Since is the time the title is known to be valid (dataset snapshot date), moves before it are ignored.
+type TitleHistoryOptions struct {
+	Since time.Time
+}
+
+// TitlePeriod time period the page had the title, zero From means the start is unknown, zero To means the title is current.
+type TitlePeriod struct {
+	Title string
+	From  time.Time
+	To    time.Time
+}
+
+// TitleHistory current identity of the page known by the requested title, with all the titles it had since then.
+type TitleHistory struct {
+	Title        string
+	CurrentTitle string
+	PageID       int
+	Periods      []TitlePeriod
+}
+
+// lastTitle get the title after the last move.
+func (hist *TitleHistory) lastTitle() string {
+	return hist.Periods[len(hist.Periods)-1].Title
+}
+
+// resolve set the current page from the page data of the last title, keyed by the title.
+func (hist *TitleHistory) resolve(pages map[string]PageData) error {
+	page, ok := pages[hist.lastTitle()]
+
+	if !ok {
+		return ErrPageNotFound
+	}
+
+	// the last title was turned into redirect without a move (merge), the period of the target is unknown
+	if len(page.RedirectedFrom) > 0 {
+		hist.Periods = append(hist.Periods, TitlePeriod{Title: page.Title})
+	}
+
+	hist.CurrentTitle, hist.PageID = page.Title, page.PageID
+	return nil
+}
+
+type logEventsResponse struct {
+	Query struct {
+		LogEvents []struct {
+			LogID     int       `json:"logid"`
+			Ns        int       `json:"ns"`
+			Title     string    `json:"title"`
+			PageID    int       `json:"pageid"`
+			Type      string    `json:"type"`
+			Action    string    `json:"action"`
+			Timestamp time.Time `json:"timestamp"`
+			Params    struct {
+				TargetNs    int    `json:"target_ns"`
+				TargetTitle string `json:"target_title"`
+			} `json:"params"`
+		} `json:"logevents"`
+	} `json:"query"`
+	Error *APIError `json:"error"`
+}
+
+// titleHistoryParams query for the moves of the title starting at the time.
+func titleHistoryParams(title string, start time.Time) url.Values {
+	params := url.Values{
+		"action":        []string{"query"},
+		"list":          []string{"logevents"},
+		"letype":        []string{"move"},
+		"letitle":       []string{title},
+		"ledir":         []string{"newer"},
+		"leprop":        []string{"ids|title|type|timestamp|details"},
+		"lelimit":       []string{"10"},
+		"format":        []string{"json"},
+		"formatversion": []string{"2"},
+	}
+
+	if !start.IsZero() {
+		params.Set("lestart", start.UTC().Format(time.RFC3339))
+	}
+
+	return params
+}
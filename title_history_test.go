@@ -0,0 +1,100 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const titleHistoryTestURL = "/title-history"
+const titleHistoryTestPageDataURL = "/title-history-page-data"
+
+func createTitleHistoryServer(batches *[]string) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(titleHistoryTestURL, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		res := `{"query": {"logevents": []}}`
+
+		switch {
+		case query.Get("letitle") == "Einstein" && query.Get("lestart") == "":
+			res = `{"query": {"logevents": [
+				{"logid": 1, "ns": 0, "title": "Einstein", "pageid": 736, "type": "move", "action": "move", "timestamp": "2005-01-01T00:00:00Z", "params": {"target_ns": 0, "target_title": "A. Einstein"}},
+				{"logid": 5, "ns": 0, "title": "Einstein", "pageid": 900, "type": "move", "action": "move", "timestamp": "2012-01-01T00:00:00Z", "params": {"target_ns": 0, "target_title": "Einstein (disambiguation)"}}
+			]}}`
+		case query.Get("letitle") == "A. Einstein" && query.Get("lestart") == "2005-01-01T00:00:00Z":
+			res = `{"query": {"logevents": [
+				{"logid": 2, "ns": 0, "title": "A. Einstein", "pageid": 736, "type": "move", "action": "move_redir", "timestamp": "2008-01-01T00:00:00Z", "params": {"target_ns": 0, "target_title": "Albert Einstein"}}
+			]}}`
+		case query.Get("letitle") == "Merged":
+			res = `{"query": {"logevents": []}}`
+		}
+
+		_, _ = w.Write([]byte(res))
+	})
+
+	router.HandleFunc(titleHistoryTestPageDataURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		redirects, pages := []string{}, []string{}
+
+		for _, title := range strings.Split(r.Form.Get("titles"), "|") {
+			switch title {
+			case "Albert Einstein":
+				pages = append(pages, `{"pageid": 736, "ns": 0, "title": "Albert Einstein"}`)
+			case "Merged":
+				redirects = append(redirects, `{"from": "Merged", "to": "Target"}`)
+				pages = append(pages, `{"pageid": 10, "ns": 0, "title": "Target"}`)
+			default:
+				pages = append(pages, `{"ns": 0, "title": "`+title+`", "missing": true}`)
+			}
+		}
+
+		*batches = append(*batches, r.Form.Get("titles"))
+		_, _ = w.Write([]byte(`{"query": {"redirects": [` + strings.Join(redirects, ",") + `], "pages": [` + strings.Join(pages, ",") + `]}}`))
+	})
+
+	return router
+}
+
+func TestResolveTitleHistory(t *testing.T) {
+	batches := []string{}
+	srv := httptest.NewServer(createTitleHistoryServer(&batches))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.TitleHistoryURL = titleHistoryTestURL
+	client.options.PageDataURL = titleHistoryTestPageDataURL
+	ctx := context.Background()
+
+	hist, err := client.ResolveTitleHistory(ctx, "Einstein")
+	assert.NoError(t, err)
+	assert.Equal(t, "Albert Einstein", hist.CurrentTitle)
+	assert.Equal(t, 736, hist.PageID)
+	assert.Equal(t, []TitlePeriod{
+		{Title: "Einstein", To: time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "A. Einstein", From: time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Title: "Albert Einstein", From: time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, hist.Periods)
+
+	hist, err = client.ResolveTitleHistory(ctx, "Merged")
+	assert.NoError(t, err)
+	assert.Equal(t, "Target", hist.CurrentTitle)
+	assert.Len(t, hist.Periods, 2)
+
+	_, err = client.ResolveTitleHistory(ctx, "Nowhere")
+	assert.True(t, errors.Is(err, ErrPageNotFound))
+
+	batches = batches[:0]
+	hists, err := client.ResolveTitleHistories(ctx, []string{"Einstein", "Merged", "Nowhere"})
+	assert.NoError(t, err)
+	assert.Len(t, hists, 2)
+	assert.Equal(t, 10, hists["Merged"].PageID)
+	assert.Equal(t, "Albert Einstein", hists["Einstein"].CurrentTitle)
+	assert.Equal(t, []string{"Albert Einstein|Merged|Nowhere"}, batches)
+}
@@ -0,0 +1,270 @@
+package mediawiki
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const titlesURL = "/w/api.php?action=query&format=json&meta=siteinfo&siprop=namespaces%7Cnamespacealiases&formatversion=2"
+
+// Namespace case rules.
+const (
+	NamespaceCaseFirstLetter = "first-letter"
+	NamespaceCaseSensitive   = "case-sensitive"
+)
+
+// Well known namespace identifiers.
+const (
+	NamespaceMedia   = -2
+	NamespaceSpecial = -1
+	NamespaceMain    = 0
+)
+
+const titleMaxLength = 255
+
+// ErrInvalidTitle title can't be parsed.
+var ErrInvalidTitle = errors.New("invalid title")
+
+// ErrNoTalkNamespace namespace has no talk or subject pair.
+var ErrNoTalkNamespace = errors.New("namespace has no talk pair")
+
+// titleIllegalRx characters and sequences not allowed in titles, same as $wgLegalTitleChars with
+// url escapes and html entities which are rejected by MediaWiki as well.
+var titleIllegalRx = regexp.MustCompile(`[\x00-\x1f\x7f<>\[\]{}|\x{fffd}]|%[0-9A-Fa-f]{2}|&[A-Za-z0-9\x80-\x{10ffff}]+;|&#[0-9]+;|&#x[0-9A-Fa-f]+;`)
+
+// titleWhitespaceRx characters treated as a space, underscore included.
+var titleWhitespaceRx = regexp.MustCompile(`[ _\x{a0}\x{1680}\x{180e}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}]+`)
+
+// titleDirectionRx invisible direction marks stripped from titles.
+var titleDirectionRx = regexp.MustCompile(`[\x{200e}\x{200f}\x{202a}-\x{202e}]`)
+
+// NamespaceAlias alternative name of the namespace.
+type NamespaceAlias struct {
+	ID    int    `json:"id"`
+	Alias string `json:"alias"`
+}
+
+type titlesResponse struct {
+	Query struct {
+		Namespaces       map[int]Namespace `json:"namespaces"`
+		NamespaceAliases []NamespaceAlias  `json:"namespacealiases"`
+	} `json:"query"`
+}
+
+// Title parsed page title.
+type Title struct {
+	Namespace     int
+	NamespaceName string
+	Text          string
+	Fragment      string
+}
+
+// DBKey get title as stored in the database, with namespace prefix and underscores.
+func (tlt *Title) DBKey() string {
+	return strings.ReplaceAll(tlt.String(), " ", "_")
+}
+
+// String get display form of the title with namespace prefix and spaces.
+func (tlt *Title) String() string {
+	if len(tlt.NamespaceName) == 0 {
+		return tlt.Text
+	}
+
+	return tlt.NamespaceName + ":" + tlt.Text
+}
+
+// IsTalk check if title belongs to talk namespace.
+func (tlt *Title) IsTalk() bool {
+	return tlt.Namespace > NamespaceMain && tlt.Namespace%2 == 1
+}
+
+// Titles offline title parser and normalizer built from the wiki namespaces.
+type Titles struct {
+	namespaces map[int]Namespace
+	names      map[string]int
+}
+
+// NewTitles create title parser from namespaces and optional namespace aliases.
+func NewTitles(namespaces []Namespace, aliases ...NamespaceAlias) *Titles {
+	tts := &Titles{
+		namespaces: map[int]Namespace{},
+		names:      map[string]int{},
+	}
+
+	for _, ns := range namespaces {
+		tts.namespaces[ns.ID] = ns
+	}
+
+	for _, alias := range aliases {
+		tts.names[namespaceKey(alias.Alias)] = alias.ID
+	}
+
+	// canonical and localized names win over aliases
+	for _, ns := range namespaces {
+		if len(ns.Canonical) > 0 {
+			tts.names[namespaceKey(ns.Canonical)] = ns.ID
+		}
+
+		if len(ns.Name) > 0 {
+			tts.names[namespaceKey(ns.Name)] = ns.ID
+		}
+	}
+
+	return tts
+}
+
+// Parse parse raw title into namespace, text and fragment, applying the same normalization as MediaWiki.
+func (tts *Titles) Parse(raw string) (*Title, error) {
+	title := new(Title)
+	text := norm.NFC.String(raw)
+	text = titleDirectionRx.ReplaceAllString(text, "")
+
+	if idx := strings.IndexByte(text, '#'); idx != -1 {
+		title.Fragment = strings.TrimSpace(titleWhitespaceRx.ReplaceAllString(text[idx+1:], " "))
+		text = text[:idx]
+	}
+
+	text = strings.TrimSpace(titleWhitespaceRx.ReplaceAllString(text, " "))
+
+	if strings.HasPrefix(text, ":") {
+		text = strings.TrimLeft(text[1:], " ")
+	}
+
+	if idx := strings.IndexByte(text, ':'); idx != -1 {
+		if id, ok := tts.names[namespaceKey(text[:idx])]; ok {
+			title.Namespace = id
+			text = strings.TrimLeft(text[idx+1:], " ")
+		}
+	}
+
+	if err := validateTitleText(raw, title.Namespace, text); err != nil {
+		return nil, err
+	}
+
+	title.NamespaceName = tts.namespaces[title.Namespace].Name
+	title.Text = tts.applyCase(title.Namespace, text)
+	return title, nil
+}
+
+// Normalize get display form of the title, useful as a key when comparing titles.
+func (tts *Titles) Normalize(raw string) (string, error) {
+	title, err := tts.Parse(raw)
+
+	if err != nil {
+		return "", err
+	}
+
+	return title.String(), nil
+}
+
+// Talk get talk page of the title, talk pages are returned as is.
+func (tts *Titles) Talk(title *Title) (*Title, error) {
+	if title.IsTalk() {
+		return title, nil
+	}
+
+	return tts.pair(title, title.Namespace+1)
+}
+
+// Subject get subject page of the title, subject pages are returned as is.
+func (tts *Titles) Subject(title *Title) (*Title, error) {
+	if !title.IsTalk() {
+		return title, nil
+	}
+
+	return tts.pair(title, title.Namespace-1)
+}
+
+// Namespace get namespace by id.
+func (tts *Titles) Namespace(id int) (Namespace, bool) {
+	ns, ok := tts.namespaces[id]
+	return ns, ok
+}
+
+// NamespaceID find namespace by canonical, localized name or alias.
+func (tts *Titles) NamespaceID(name string) (int, bool) {
+	id, ok := tts.names[namespaceKey(name)]
+	return id, ok
+}
+
+func (tts *Titles) pair(title *Title, id int) (*Title, error) {
+	ns, ok := tts.namespaces[id]
+
+	if title.Namespace < NamespaceMain || !ok {
+		return nil, ErrNoTalkNamespace
+	}
+
+	return &Title{
+		Namespace:     id,
+		NamespaceName: ns.Name,
+		Text:          tts.applyCase(id, title.Text),
+	}, nil
+}
+
+func (tts *Titles) applyCase(id int, text string) string {
+	if ns, ok := tts.namespaces[id]; ok && ns.Case == NamespaceCaseSensitive {
+		return text
+	}
+
+	r, size := utf8.DecodeRuneInString(text)
+
+	if r == utf8.RuneError {
+		return text
+	}
+
+	return string(unicode.ToUpper(r)) + text[size:]
+}
+
+func validateTitleText(raw string, ns int, text string) error {
+	if len(text) == 0 {
+		return invalidTitle(raw, "empty title")
+	}
+
+	if match := titleIllegalRx.FindString(text); len(match) > 0 {
+		return invalidTitle(raw, fmt.Sprintf("illegal characters %q", match))
+	}
+
+	if strings.HasPrefix(text, ":") {
+		return invalidTitle(raw, "leading colon")
+	}
+
+	if text == "." || text == ".." ||
+		strings.HasPrefix(text, "./") ||
+		strings.HasPrefix(text, "../") ||
+		strings.Contains(text, "/./") ||
+		strings.Contains(text, "/../") ||
+		strings.HasSuffix(text, "/.") ||
+		strings.HasSuffix(text, "/..") {
+		return invalidTitle(raw, "relative path")
+	}
+
+	if strings.Contains(text, "~~~") {
+		return invalidTitle(raw, "signature")
+	}
+
+	if ns != NamespaceSpecial && len(text) > titleMaxLength {
+		return invalidTitle(raw, "title is too long")
+	}
+
+	return nil
+}
+
+func invalidTitle(raw string, reason string) error {
+	return fmt.Errorf("%w: %q %s", ErrInvalidTitle, raw, reason)
+}
+
+func namespaceKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(titleWhitespaceRx.ReplaceAllString(name, " ")))
+}
+
+// escapeTitle escape title to be used as a path segment of REST API urls.
+func escapeTitle(title string) string {
+	return url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+}
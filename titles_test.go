@@ -0,0 +1,163 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const titlesTestURL = "/titles"
+const titlesTestResponse = `{
+	"batchcomplete": true,
+	"query": {
+		"namespaces": {
+			"-1": { "id": -1, "case": "first-letter", "name": "Служебная", "canonical": "Special" },
+			"0": { "id": 0, "case": "first-letter", "name": "" },
+			"1": { "id": 1, "case": "first-letter", "name": "Обсуждение", "canonical": "Talk" },
+			"2": { "id": 2, "case": "first-letter", "name": "Участник", "canonical": "User" },
+			"3": { "id": 3, "case": "first-letter", "name": "Обсуждение участника", "canonical": "User talk" },
+			"14": { "id": 14, "case": "first-letter", "name": "Категория", "canonical": "Category" },
+			"15": { "id": 15, "case": "first-letter", "name": "Обсуждение категории", "canonical": "Category talk" },
+			"828": { "id": 828, "case": "case-sensitive", "name": "Модуль", "canonical": "Module" }
+		},
+		"namespacealiases": [
+			{ "id": 2, "alias": "Участница" },
+			{ "id": 2, "alias": "U" }
+		]
+	}
+}`
+
+func createTitlesServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(titlesTestURL, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(titlesTestResponse))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestTitles(t *testing.T) {
+	srv := httptest.NewServer(createTitlesServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.TitlesURL = titlesTestURL
+
+	titles, err := client.Titles(context.Background())
+	assert.NoError(t, err)
+
+	t.Run("parse", func(t *testing.T) {
+		for raw, expected := range map[string]struct {
+			ns       int
+			text     string
+			dbKey    string
+			fragment string
+		}{
+			"hello_world":                    {0, "Hello world", "Hello_world", ""},
+			"  :hello   world#History_part ": {0, "Hello world", "Hello_world", "History part"},
+			"category:Физика":                {14, "Физика", "Категория:Физика", ""},
+			"Category talk:физика":           {15, "Физика", "Обсуждение_категории:Физика", ""},
+			"участница:Example":              {2, "Example", "Участник:Example", ""},
+			"U:example":                      {2, "Example", "Участник:Example", ""},
+			"Module:lowercase":               {828, "lowercase", "Модуль:lowercase", ""},
+			"Unknown:prefix":                 {0, "Unknown:prefix", "Unknown:prefix", ""},
+			"Talk:Category:Nested":           {1, "Category:Nested", "Обсуждение:Category:Nested", ""},
+			"Café au‎ lait":                 {0, "Café au lait", "Café_au_lait", ""},
+			"Special:RecentChanges":          {-1, "RecentChanges", "Служебная:RecentChanges", ""},
+			"100% natural":                   {0, "100% natural", "100%_natural", ""},
+			"Обсуждение_участника:some user": {3, "Some user", "Обсуждение_участника:Some_user", ""},
+		} {
+			title, err := titles.Parse(raw)
+
+			if assert.NoError(t, err, raw) {
+				assert.Equal(t, expected.ns, title.Namespace, raw)
+				assert.Equal(t, expected.text, title.Text, raw)
+				assert.Equal(t, expected.dbKey, title.DBKey(), raw)
+				assert.Equal(t, expected.fragment, title.Fragment, raw)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, raw := range []string{
+			"",
+			"#fragment",
+			"Category:",
+			"Talk:  ",
+			"Foo[bar]",
+			"Foo|bar",
+			"Foo{{bar}}",
+			"a<b",
+			"Foo%20bar",
+			"Foo&amp;bar",
+			"Foo\x01bar",
+			"../Foo",
+			"Foo/./bar",
+			"Foo/..",
+			"Sign ~~~~",
+			"Category::Foo",
+		} {
+			_, err := titles.Parse(raw)
+			assert.True(t, errors.Is(err, ErrInvalidTitle), raw)
+		}
+	})
+
+	t.Run("normalize", func(t *testing.T) {
+		norm, err := titles.Normalize("category:foo_bar#top")
+		assert.NoError(t, err)
+		assert.Equal(t, "Категория:Foo bar", norm)
+	})
+
+	t.Run("talk and subject", func(t *testing.T) {
+		title, err := titles.Parse("User:Example")
+		assert.NoError(t, err)
+
+		talk, err := titles.Talk(title)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, talk.Namespace)
+		assert.Equal(t, "Обсуждение участника:Example", talk.String())
+		assert.True(t, talk.IsTalk())
+
+		subject, err := titles.Subject(talk)
+		assert.NoError(t, err)
+		assert.Equal(t, title.String(), subject.String())
+
+		special, err := titles.Parse("Special:Random")
+		assert.NoError(t, err)
+
+		_, err = titles.Talk(special)
+		assert.True(t, errors.Is(err, ErrNoTalkNamespace))
+
+		module, err := titles.Parse("Module:Foo")
+		assert.NoError(t, err)
+
+		_, err = titles.Talk(module)
+		assert.True(t, errors.Is(err, ErrNoTalkNamespace))
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		id, ok := titles.NamespaceID("user")
+		assert.True(t, ok)
+		assert.Equal(t, 2, id)
+
+		ns, ok := titles.Namespace(14)
+		assert.True(t, ok)
+		assert.Equal(t, "Category", ns.Canonical)
+	})
+}
+
+func TestEscapeTitle(t *testing.T) {
+	assert.Equal(t, "AC%2FDC_%28band%29", escapeTitle("AC/DC (band)"))
+	assert.Equal(t, "C++", escapeTitle("C++"))
+}
@@ -0,0 +1,337 @@
+package mediawiki
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const wikibaseURL = "/w/api.php"
+
+// Max number of entity ids wbgetentities accepts in one request.
+const wikibaseEntitiesLimit = 50
+
+// Types of entities.
+const (
+	WikibaseEntityItem     = "item"
+	WikibaseEntityProperty = "property"
+	WikibaseEntityLexeme   = "lexeme"
+	WikibaseEntityForm     = "form"
+	WikibaseEntitySense    = "sense"
+)
+
+// Types of snak data values.
+const (
+	WikibaseValueEntityID        = "wikibase-entityid"
+	WikibaseValueString          = "string"
+	WikibaseValueTime            = "time"
+	WikibaseValueQuantity        = "quantity"
+	WikibaseValueGlobeCoordinate = "globecoordinate"
+	WikibaseValueMonolingualText = "monolingualtext"
+)
+
+// Types of snaks.
+const (
+	WikibaseSnakValue     = "value"
+	WikibaseSnakSomeValue = "somevalue"
+	WikibaseSnakNoValue   = "novalue"
+)
+
+// Ranks of statements.
+const (
+	WikibaseRankPreferred  = "preferred"
+	WikibaseRankNormal     = "normal"
+	WikibaseRankDeprecated = "deprecated"
+)
+
+// ErrUnsupportedTime wikibase time can't be represented by time.Time.
+var ErrUnsupportedTime = errors.New("unsupported wikibase time")
+
+// WikibaseEntitiesOptions optional parameters for WikibaseEntities method.
+// Props limit the parts of entities returned ("info", "labels", "descriptions", "aliases", "sitelinks", "sitelinks/urls", "claims"),
+// Languages limit terms to the languages and LanguageFallback fills missing languages from fallback chain.
+type WikibaseEntitiesOptions struct {
+	Props            []string
+	Languages        []string
+	LanguageFallback bool
+	Sites            []string
+}
+
+// WikibaseTerm label, description or alias in a single language.
+// For terms taken from the fallback chain Language is the actual language and ForLanguage is the requested one.
+type WikibaseTerm struct {
+	Language    string `json:"language"`
+	Value       string `json:"value"`
+	ForLanguage string `json:"for-language,omitempty"`
+}
+
+// WikibaseSitelink link of the entity to a page on another wiki.
+type WikibaseSitelink struct {
+	Site   string   `json:"site"`
+	Title  string   `json:"title"`
+	Badges []string `json:"badges"`
+	URL    string   `json:"url,omitempty"`
+}
+
+// WikibaseEntityIDValue value pointing to another entity (item, property, lexeme and alike).
+type WikibaseEntityIDValue struct {
+	EntityType string `json:"entity-type"`
+	NumericID  int    `json:"numeric-id,omitempty"`
+	ID         string `json:"id"`
+}
+
+// WikibaseTimeValue point in time with precision (9 is year, 10 month, 11 day).
+type WikibaseTimeValue struct {
+	Time          string `json:"time"`
+	Timezone      int    `json:"timezone"`
+	Before        int    `json:"before"`
+	After         int    `json:"after"`
+	Precision     int    `json:"precision"`
+	CalendarModel string `json:"calendarmodel"`
+}
+
+// Value convert to time.Time, unknown month or day (written as "00") are set to the first one.
+// Years before the common era and after 9999 are not supported.
+func (tv *WikibaseTimeValue) Value() (time.Time, error) {
+	value := tv.Time
+
+	if strings.HasPrefix(value, "-") {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrUnsupportedTime, tv.Time)
+	}
+
+	value = strings.TrimPrefix(value, "+")
+
+	if len(value) != len("2006-01-02T15:04:05Z") {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrUnsupportedTime, tv.Time)
+	}
+
+	if value[5:7] == "00" {
+		value = value[:5] + "01" + value[7:]
+	}
+
+	if value[8:10] == "00" {
+		value = value[:8] + "01" + value[10:]
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// WikibaseQuantityValue amount with optional bounds and unit ("1" for unitless or entity URI).
+type WikibaseQuantityValue struct {
+	Amount     string `json:"amount"`
+	UpperBound string `json:"upperBound,omitempty"`
+	LowerBound string `json:"lowerBound,omitempty"`
+	Unit       string `json:"unit"`
+}
+
+// WikibaseGlobeCoordinateValue geographic coordinates.
+type WikibaseGlobeCoordinateValue struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Altitude  *float64 `json:"altitude"`
+	Precision float64  `json:"precision"`
+	Globe     string   `json:"globe"`
+}
+
+// WikibaseMonolingualTextValue text in a single language.
+type WikibaseMonolingualTextValue struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// WikibaseDataValue snak value, depending on the Type one of the typed fields is filled.
+// Values of unknown types are kept only in Raw.
+type WikibaseDataValue struct {
+	Type            string
+	String          string
+	EntityID        *WikibaseEntityIDValue
+	Time            *WikibaseTimeValue
+	Quantity        *WikibaseQuantityValue
+	GlobeCoordinate *WikibaseGlobeCoordinateValue
+	MonolingualText *WikibaseMonolingualTextValue
+	Raw             json.RawMessage
+}
+
+type wikibaseDataValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// UnmarshalJSON decode value according to it's type.
+func (dv *WikibaseDataValue) UnmarshalJSON(data []byte) error {
+	raw := new(wikibaseDataValue)
+
+	if err := json.Unmarshal(data, raw); err != nil {
+		return err
+	}
+
+	dv.Type, dv.Raw = raw.Type, raw.Value
+
+	switch raw.Type {
+	case WikibaseValueString:
+		return json.Unmarshal(raw.Value, &dv.String)
+	case WikibaseValueEntityID:
+		dv.EntityID = new(WikibaseEntityIDValue)
+		return json.Unmarshal(raw.Value, dv.EntityID)
+	case WikibaseValueTime:
+		dv.Time = new(WikibaseTimeValue)
+		return json.Unmarshal(raw.Value, dv.Time)
+	case WikibaseValueQuantity:
+		dv.Quantity = new(WikibaseQuantityValue)
+		return json.Unmarshal(raw.Value, dv.Quantity)
+	case WikibaseValueGlobeCoordinate:
+		dv.GlobeCoordinate = new(WikibaseGlobeCoordinateValue)
+		return json.Unmarshal(raw.Value, dv.GlobeCoordinate)
+	case WikibaseValueMonolingualText:
+		dv.MonolingualText = new(WikibaseMonolingualTextValue)
+		return json.Unmarshal(raw.Value, dv.MonolingualText)
+	}
+
+	return nil
+}
+
+// MarshalJSON encode value back into the wikibase format.
+func (dv WikibaseDataValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":  dv.Type,
+		"value": dv.value(),
+	})
+}
+
+func (dv *WikibaseDataValue) value() interface{} {
+	switch {
+	case dv.EntityID != nil:
+		return dv.EntityID
+	case dv.Time != nil:
+		return dv.Time
+	case dv.Quantity != nil:
+		return dv.Quantity
+	case dv.GlobeCoordinate != nil:
+		return dv.GlobeCoordinate
+	case dv.MonolingualText != nil:
+		return dv.MonolingualText
+	case dv.Type == WikibaseValueString:
+		return dv.String
+	}
+
+	return dv.Raw
+}
+
+// WikibaseSnak property and it's value, DataValue is nil for "somevalue" and "novalue" snaks.
+type WikibaseSnak struct {
+	SnakType  string             `json:"snaktype"`
+	Property  string             `json:"property"`
+	Hash      string             `json:"hash,omitempty"`
+	DataType  string             `json:"datatype,omitempty"`
+	DataValue *WikibaseDataValue `json:"datavalue,omitempty"`
+}
+
+// WikibaseReference source of the statement.
+type WikibaseReference struct {
+	Hash       string                    `json:"hash,omitempty"`
+	Snaks      map[string][]WikibaseSnak `json:"snaks"`
+	SnaksOrder []string                  `json:"snaks-order,omitempty"`
+}
+
+// WikibaseClaim statement about the entity.
+type WikibaseClaim struct {
+	ID              string                    `json:"id,omitempty"`
+	Type            string                    `json:"type"`
+	Rank            string                    `json:"rank"`
+	MainSnak        WikibaseSnak              `json:"mainsnak"`
+	Qualifiers      map[string][]WikibaseSnak `json:"qualifiers,omitempty"`
+	QualifiersOrder []string                  `json:"qualifiers-order,omitempty"`
+	References      []WikibaseReference       `json:"references,omitempty"`
+}
+
+// WikibaseEntity item or property, only the requested parts are filled.
+type WikibaseEntity struct {
+	ID           string                      `json:"id"`
+	Type         string                      `json:"type"`
+	PageID       int                         `json:"pageid"`
+	Ns           int                         `json:"ns"`
+	Title        string                      `json:"title"`
+	LastRevID    int                         `json:"lastrevid"`
+	Modified     time.Time                   `json:"modified"`
+	Missing      WikibaseFlag                `json:"missing"`
+	Redirects    *WikibaseRedirect           `json:"redirects"`
+	Labels       map[string]WikibaseTerm     `json:"labels"`
+	Descriptions map[string]WikibaseTerm     `json:"descriptions"`
+	Aliases      map[string][]WikibaseTerm   `json:"aliases"`
+	Sitelinks    map[string]WikibaseSitelink `json:"sitelinks"`
+	Claims       map[string][]WikibaseClaim  `json:"claims"`
+
+	// lexemes
+	Lemmas          map[string]WikibaseTerm `json:"lemmas,omitempty"`
+	LexicalCategory string                  `json:"lexicalCategory,omitempty"`
+	Language        string                  `json:"language,omitempty"`
+	Forms           []WikibaseForm          `json:"forms,omitempty"`
+	Senses          []WikibaseSense         `json:"senses,omitempty"`
+
+	// forms and senses requested by their own ids ("L7-F1", "L7-S1")
+	Representations     map[string]WikibaseTerm `json:"representations,omitempty"`
+	GrammaticalFeatures []string                `json:"grammaticalFeatures,omitempty"`
+	Glosses             map[string]WikibaseTerm `json:"glosses,omitempty"`
+}
+
+// WikibaseFlag flag set by the presence of the field, wikibase API returns flags as empty strings ("missing": "").
+type WikibaseFlag bool
+
+// UnmarshalJSON set the flag for any value except false and null.
+func (flag *WikibaseFlag) UnmarshalJSON(data []byte) error {
+	value := string(bytes.TrimSpace(data))
+	*flag = WikibaseFlag(value != "false" && value != "null")
+	return nil
+}
+
+// WikibaseRedirect requested entity id that was redirected to the entity.
+type WikibaseRedirect struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Label get label in the first available language.
+func (ent *WikibaseEntity) Label(langs ...string) string {
+	return wikibaseTerm(ent.Labels, langs)
+}
+
+// Description get description in the first available language.
+func (ent *WikibaseEntity) Description(langs ...string) string {
+	return wikibaseTerm(ent.Descriptions, langs)
+}
+
+// Statements get claims of the property without deprecated ones, preferred claims go first.
+func (ent *WikibaseEntity) Statements(property string) []WikibaseClaim {
+	preferred := []WikibaseClaim{}
+	normal := []WikibaseClaim{}
+
+	for _, claim := range ent.Claims[property] {
+		switch claim.Rank {
+		case WikibaseRankPreferred:
+			preferred = append(preferred, claim)
+		case WikibaseRankDeprecated:
+		default:
+			normal = append(normal, claim)
+		}
+	}
+
+	return append(preferred, normal...)
+}
+
+func wikibaseTerm(terms map[string]WikibaseTerm, langs []string) string {
+	for _, lang := range langs {
+		if term, ok := terms[lang]; ok {
+			return term.Value
+		}
+	}
+
+	return ""
+}
+
+type wikibaseEntitiesResponse struct {
+	Entities map[string]WikibaseEntity `json:"entities"`
+	Error    *APIError                 `json:"error"`
+}
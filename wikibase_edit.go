@@ -0,0 +1,187 @@
+package mediawiki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const tokenURL = "/w/api.php"
+
+// Entity data of the exact revision, used to get the entity after the edit without replica lag.
+const entityDataURL = "/wiki/Special:EntityData/%s.json"
+
+// ErrEditConflict entity was changed after the base revision.
+var ErrEditConflict = errors.New("edit conflict")
+
+// ErrBadToken csrf token is invalid or expired.
+var ErrBadToken = errors.New("bad token")
+
+// apiErrorCodes sentinel errors matched by the api error codes.
+var apiErrorCodes = map[error][]string{
+	ErrEditConflict: {"editconflict"},
+	ErrBadToken:     {"badtoken", "notoken"},
+}
+
+// WikibaseEditOptions optional parameters of every wikibase edit.
+// BaseRevID is the revision the edit is based on, the edit fails with ErrEditConflict
+// if the entity was changed in the meantime and the change can't be merged.
+type WikibaseEditOptions struct {
+	BaseRevID int
+	Summary   string
+	Tags      []string
+	Bot       bool
+}
+
+// WikibaseEditEntityOptions optional parameters for WikibaseEditEntity method.
+// Clear replaces the whole entity with the data instead of merging.
+type WikibaseEditEntityOptions struct {
+	WikibaseEditOptions
+	Clear bool
+}
+
+// WikibaseFetchError edit was applied, but getting the updated entity failed, the edit should not be retried.
+type WikibaseFetchError struct {
+	ID    string
+	RevID int
+	Err   error
+}
+
+// Error get error message.
+func (err *WikibaseFetchError) Error() string {
+	return fmt.Sprintf("edit of '%s' applied as revision '%d', fetching the entity failed: %v", err.ID, err.RevID, err.Err)
+}
+
+// Unwrap get the underlying error.
+func (err *WikibaseFetchError) Unwrap() error {
+	return err.Err
+}
+
+// WikibaseAliasesChange aliases to add and remove, Set replaces all the aliases in the language.
+type WikibaseAliasesChange struct {
+	Add    []string
+	Remove []string
+	Set    []string
+}
+
+type wikibaseEditResponse struct {
+	Success  int             `json:"success"`
+	Entity   *WikibaseEntity `json:"entity"`
+	PageInfo struct {
+		LastRevID int `json:"lastrevid"`
+	} `json:"pageinfo"`
+	Claim *WikibaseClaim `json:"claim"`
+	Error *APIError      `json:"error"`
+}
+
+// revision get the revision created by the edit.
+func (res *wikibaseEditResponse) revision() int {
+	if res.PageInfo.LastRevID > 0 {
+		return res.PageInfo.LastRevID
+	}
+
+	if res.Entity != nil {
+		return res.Entity.LastRevID
+	}
+
+	return 0
+}
+
+type entityDataResponse struct {
+	Entities map[string]WikibaseEntity `json:"entities"`
+}
+
+type tokensResponse struct {
+	Query struct {
+		Tokens struct {
+			CSRFToken string `json:"csrftoken"`
+		} `json:"tokens"`
+	} `json:"query"`
+	Error *APIError `json:"error"`
+}
+
+// tokenCache csrf token shared between edits of the client.
+type tokenCache struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (tc *tokenCache) get() string {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.token
+}
+
+func (tc *tokenCache) set(token string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.token = token
+}
+
+// wikibaseEntityData entity parts accepted by wbeditentity.
+func wikibaseEntityData(entity *WikibaseEntity) ([]byte, error) {
+	data := map[string]interface{}{}
+
+	if entity.Labels != nil {
+		data["labels"] = entity.Labels
+	}
+
+	if entity.Descriptions != nil {
+		data["descriptions"] = entity.Descriptions
+	}
+
+	if entity.Aliases != nil {
+		data["aliases"] = entity.Aliases
+	}
+
+	if entity.Sitelinks != nil {
+		data["sitelinks"] = entity.Sitelinks
+	}
+
+	if entity.Claims != nil {
+		data["claims"] = entity.Claims
+	}
+
+	if entity.Lemmas != nil {
+		data["lemmas"] = entity.Lemmas
+	}
+
+	if len(entity.LexicalCategory) > 0 {
+		data["lexicalCategory"] = entity.LexicalCategory
+	}
+
+	if len(entity.Language) > 0 {
+		data["language"] = entity.Language
+	}
+
+	if entity.Forms != nil {
+		data["forms"] = entity.Forms
+	}
+
+	if entity.Senses != nil {
+		data["senses"] = entity.Senses
+	}
+
+	return json.Marshal(data)
+}
+
+// wikibaseSnakValue encode snak value for wbcreateclaim and wbsetqualifier.
+func wikibaseSnakValue(snak *WikibaseSnak) (string, error) {
+	if snak.DataValue == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(snak.DataValue.value())
+	return string(data), err
+}
+
+// wikibaseEntityID get entity id from the statement id ("Q42$5627445f-43cb-ed6d-3adb-760e85bd17ee").
+func wikibaseEntityID(guid string) string {
+	if idx := strings.IndexByte(guid, '$'); idx != -1 {
+		return strings.ToUpper(guid[:idx])
+	}
+
+	return guid
+}
@@ -0,0 +1,229 @@
+package mediawiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wikibaseEditTestURL = "/wikibase-edit"
+const wikibaseEditTestTokenURL = "/token"
+const wikibaseEditTestEntityDataURL = "/entity-data/"
+const wikibaseEditTestEntity = `{"id": "Q42", "type": "item", "lastrevid": 1501, "labels": {"en": {"language": "en", "value": "Douglas Adams"}}}`
+
+func createWikibaseEditServer(tokens *int, edits *[]url.Values) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(wikibaseEditTestTokenURL, func(w http.ResponseWriter, r *http.Request) {
+		*tokens++
+		token := `expired+\\`
+
+		if *tokens > 1 {
+			token = `fresh+\\`
+		}
+
+		_, err := w.Write([]byte(`{"batchcomplete": true, "query": {"tokens": {"csrftoken": "` + token + `"}}}`))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	router.HandleFunc(wikibaseEditTestEntityDataURL, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == wikibaseEditTestEntityDataURL+"Q42.json" && r.URL.Query().Get("revision") == "1502":
+			_, _ = w.Write([]byte(`{"entities": {"Q42": {"id": "Q42", "type": "item", "lastrevid": 1502,
+				"labels": {"en": {"language": "en", "value": "Douglas Adams"}},
+				"claims": {"P31": [{"id": "Q42$abc", "type": "statement", "rank": "normal", "mainsnak": {"snaktype": "value", "property": "P31"}}]}
+			}}}`))
+		case r.URL.Path == wikibaseEditTestEntityDataURL+"Q42.json" && r.URL.Query().Get("revision") == "1501":
+			_, _ = w.Write([]byte(`{"entities": {"Q42": ` + wikibaseEditTestEntity + `}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	router.HandleFunc(wikibaseEditTestURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		res := ""
+
+		switch {
+		case r.Form.Get("action") == "wbgetentities":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case r.Form.Get("token") != `fresh+\`:
+			res = `{"error": {"code": "badtoken", "info": "Invalid CSRF token."}}`
+		case r.Form.Get("baserevid") == "1":
+			*edits = append(*edits, r.Form)
+			res = `{"error": {"code": "editconflict", "info": "Edit conflict."}}`
+		case r.Form.Get("action") == "wbeditentity" && r.Form.Get("id") == "Q42":
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "pageinfo": {"lastrevid": 1501}}`
+		case r.Form.Get("action") == "wbeditentity":
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "entity": {"id": "Q43", "type": "item", "lastrevid": 1}}`
+		case r.Form.Get("action") == "wbsetlabel":
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "entity": {"id": "Q42", "type": "item", "lastrevid": 1501, "labels": {"en": {"language": "en", "value": "Douglas Adams"}}}}`
+		case r.Form.Get("action") == "wbsetsitelink":
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "pageinfo": {"lastrevid": 1503}}`
+		case r.Form.Get("action") == "wbsetclaim" || r.Form.Get("action") == "wbcreateclaim":
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "pageinfo": {"lastrevid": 1502}, "claim": {"id": "Q42$abc", "type": "statement", "rank": "normal", "mainsnak": {"snaktype": "value", "property": "P31"}}}`
+		default:
+			*edits = append(*edits, r.Form)
+			res = `{"success": 1, "pageinfo": {"lastrevid": 1501}}`
+		}
+
+		_, err := w.Write([]byte(res))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestWikibaseEdit(t *testing.T) {
+	tokens := 0
+	edits := []url.Values{}
+	srv := httptest.NewServer(createWikibaseEditServer(&tokens, &edits))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.WikibaseURL = wikibaseEditTestURL
+	client.options.TokenURL = wikibaseEditTestTokenURL
+	client.options.EntityDataURL = wikibaseEditTestEntityDataURL + "%s.json"
+	ctx := context.Background()
+	opts := WikibaseEditOptions{BaseRevID: 1500, Summary: "test", Tags: []string{"bot-import"}, Bot: true}
+
+	t.Run("set label", func(t *testing.T) {
+		entity, err := client.WikibaseSetLabel(ctx, "q42", "en", "Douglas Adams", opts)
+		assert.NoError(t, err)
+		assert.Equal(t, 1501, entity.LastRevID)
+		assert.Equal(t, "Douglas Adams", entity.Label("en"))
+		assert.Equal(t, 2, tokens)
+
+		edit := edits[len(edits)-1]
+		assert.Equal(t, "wbsetlabel", edit.Get("action"))
+		assert.Equal(t, "1500", edit.Get("baserevid"))
+		assert.Equal(t, "test", edit.Get("summary"))
+		assert.Equal(t, "bot-import", edit.Get("tags"))
+		assert.Equal(t, "1", edit.Get("bot"))
+	})
+
+	t.Run("edit conflict", func(t *testing.T) {
+		_, err := client.WikibaseSetDescription(ctx, "Q42", "en", "writer", WikibaseEditOptions{BaseRevID: 1})
+		assert.True(t, errors.Is(err, ErrEditConflict))
+		assert.Equal(t, 2, tokens)
+	})
+
+	t.Run("edit entity", func(t *testing.T) {
+		entity, err := client.WikibaseEditEntity(ctx, &WikibaseEntity{
+			PageID: 100,
+			Labels: map[string]WikibaseTerm{"en": {Language: "en", Value: "New item"}},
+		}, WikibaseEditEntityOptions{Clear: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "Q43", entity.ID)
+
+		edit := edits[len(edits)-1]
+		assert.Equal(t, "item", edit.Get("new"))
+		assert.Equal(t, "1", edit.Get("clear"))
+		assert.JSONEq(t, `{"labels": {"en": {"language": "en", "value": "New item"}}}`, edit.Get("data"))
+
+		entity, err = client.WikibaseEditEntity(ctx, &WikibaseEntity{ID: "Q42"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1501, entity.LastRevID)
+		assert.Equal(t, "Douglas Adams", entity.Label("en"))
+	})
+
+	t.Run("aliases and sitelink", func(t *testing.T) {
+		_, err := client.WikibaseSetAliases(ctx, "Q42", "en", WikibaseAliasesChange{Add: []string{"DNA", "Bop Ad"}, Remove: []string{"DA"}})
+		assert.NoError(t, err)
+
+		edit := edits[len(edits)-1]
+		assert.Equal(t, "DNA|Bop Ad", edit.Get("add"))
+		assert.Equal(t, "DA", edit.Get("remove"))
+		assert.Empty(t, edit["set"])
+
+		_, err = client.WikibaseSetSitelink(ctx, "Q42", "enwiki", "Douglas Adams", []string{"Q17437796"})
+		fetch := new(WikibaseFetchError)
+		assert.True(t, errors.As(err, &fetch))
+		assert.Equal(t, 1503, fetch.RevID)
+		assert.False(t, errors.Is(err, ErrEditConflict))
+
+		edit = edits[len(edits)-1]
+		assert.Equal(t, "enwiki", edit.Get("linksite"))
+		assert.Equal(t, "Douglas Adams", edit.Get("linktitle"))
+		assert.Equal(t, "Q17437796", edit.Get("badges"))
+	})
+
+	t.Run("claims", func(t *testing.T) {
+		snak := WikibaseSnak{
+			SnakType: WikibaseSnakValue,
+			Property: "P31",
+			DataValue: &WikibaseDataValue{
+				Type:     WikibaseValueEntityID,
+				EntityID: &WikibaseEntityIDValue{EntityType: "item", NumericID: 5, ID: "Q5"},
+			},
+		}
+
+		_, err := client.WikibaseCreateClaim(ctx, "Q42", snak)
+		assert.NoError(t, err)
+
+		edit := edits[len(edits)-1]
+		assert.Equal(t, "wbcreateclaim", edit.Get("action"))
+		assert.Equal(t, "Q42", edit.Get("entity"))
+		assert.JSONEq(t, `{"entity-type": "item", "numeric-id": 5, "id": "Q5"}`, edit.Get("value"))
+
+		claim := WikibaseClaim{ID: "q42$abc", Type: "statement", Rank: WikibaseRankNormal, MainSnak: snak}
+		entity, err := client.WikibaseSetClaim(ctx, claim)
+		assert.NoError(t, err)
+		assert.Equal(t, "Q42", entity.ID)
+		assert.Equal(t, 1502, entity.LastRevID)
+		assert.Equal(t, "Q42$abc", entity.Claims["P31"][0].ID)
+		assert.Equal(t, "Douglas Adams", entity.Label("en"))
+
+		decoded := WikibaseClaim{}
+		assert.NoError(t, json.Unmarshal([]byte(edits[len(edits)-1].Get("claim")), &decoded))
+		assert.Equal(t, "Q5", decoded.MainSnak.DataValue.EntityID.ID)
+
+		_, err = client.WikibaseSetQualifier(ctx, "Q42$abc", WikibaseSnak{
+			SnakType:  WikibaseSnakValue,
+			Property:  "P1480",
+			Hash:      "hash",
+			DataValue: &WikibaseDataValue{Type: WikibaseValueString, String: "circa"},
+		})
+		assert.NoError(t, err)
+
+		edit = edits[len(edits)-1]
+		assert.Equal(t, `"circa"`, edit.Get("value"))
+		assert.Equal(t, "hash", edit.Get("snakhash"))
+
+		_, err = client.WikibaseSetReference(ctx, "Q42$abc", WikibaseReference{
+			Snaks:      map[string][]WikibaseSnak{"P248": {snak}},
+			SnaksOrder: []string{"P248"},
+		})
+		assert.NoError(t, err)
+
+		edit = edits[len(edits)-1]
+		assert.Equal(t, "Q42$abc", edit.Get("statement"))
+		assert.Equal(t, `["P248"]`, edit.Get("snaks-order"))
+		assert.Empty(t, edit["reference"])
+
+		entity, err = client.WikibaseRemoveClaims(ctx, []string{"Q42$abc", "Q42$def"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Q42", entity.ID)
+		assert.Equal(t, 1501, entity.LastRevID)
+		assert.Equal(t, "Q42$abc|Q42$def", edits[len(edits)-1].Get("claim"))
+	})
+}
@@ -0,0 +1,70 @@
+package mediawiki
+
+// WikibaseForm grammatical form of the lexeme.
+type WikibaseForm struct {
+	ID                  string                     `json:"id"`
+	Representations     map[string]WikibaseTerm    `json:"representations"`
+	GrammaticalFeatures []string                   `json:"grammaticalFeatures"`
+	Claims              map[string][]WikibaseClaim `json:"claims"`
+}
+
+// Representation get form representation in the first available spelling variant.
+func (frm *WikibaseForm) Representation(langs ...string) string {
+	return wikibaseTerm(frm.Representations, langs)
+}
+
+// WikibaseSense meaning of the lexeme.
+type WikibaseSense struct {
+	ID      string                     `json:"id"`
+	Glosses map[string]WikibaseTerm    `json:"glosses"`
+	Claims  map[string][]WikibaseClaim `json:"claims"`
+}
+
+// Gloss get sense gloss in the first available language.
+func (sns *WikibaseSense) Gloss(langs ...string) string {
+	return wikibaseTerm(sns.Glosses, langs)
+}
+
+// Lemma get lexeme lemma in the first available spelling variant.
+func (ent *WikibaseEntity) Lemma(langs ...string) string {
+	return wikibaseTerm(ent.Lemmas, langs)
+}
+
+// Form find form of the lexeme by id, for form entity the entity itself is returned.
+func (ent *WikibaseEntity) Form(id string) (WikibaseForm, bool) {
+	if ent.Type == WikibaseEntityForm && ent.ID == id {
+		return WikibaseForm{
+			ID:                  ent.ID,
+			Representations:     ent.Representations,
+			GrammaticalFeatures: ent.GrammaticalFeatures,
+			Claims:              ent.Claims,
+		}, true
+	}
+
+	for _, form := range ent.Forms {
+		if form.ID == id {
+			return form, true
+		}
+	}
+
+	return WikibaseForm{}, false
+}
+
+// Sense find sense of the lexeme by id, for sense entity the entity itself is returned.
+func (ent *WikibaseEntity) Sense(id string) (WikibaseSense, bool) {
+	if ent.Type == WikibaseEntitySense && ent.ID == id {
+		return WikibaseSense{
+			ID:      ent.ID,
+			Glosses: ent.Glosses,
+			Claims:  ent.Claims,
+		}, true
+	}
+
+	for _, sense := range ent.Senses {
+		if sense.ID == id {
+			return sense, true
+		}
+	}
+
+	return WikibaseSense{}, false
+}
@@ -0,0 +1,91 @@
+package mediawiki
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wikibaseLexemeTestURL = "/wikibase-lexeme"
+const wikibaseLexemeTestResponse = `{
+	"success": 1,
+	"entities": {
+		"L7": {
+			"type": "lexeme",
+			"id": "L7",
+			"lastrevid": 10,
+			"lemmas": {"en": {"language": "en", "value": "cat"}},
+			"lexicalCategory": "Q1084",
+			"language": "Q1860",
+			"claims": {},
+			"forms": [
+				{"id": "L7-F1", "representations": {"en": {"language": "en", "value": "cat"}}, "grammaticalFeatures": ["Q110786"], "claims": {}},
+				{"id": "L7-F2", "representations": {"en": {"language": "en", "value": "cats"}}, "grammaticalFeatures": ["Q146786"], "claims": {}}
+			],
+			"senses": [
+				{"id": "L7-S1", "glosses": {"en": {"language": "en", "value": "domesticated animal"}}, "claims": {"P5137": [{"type": "statement", "rank": "normal", "mainsnak": {"snaktype": "value", "property": "P5137", "datavalue": {"type": "wikibase-entityid", "value": {"entity-type": "item", "id": "Q146"}}}}]}}
+			]
+		},
+		"L7-F2": {"type": "form", "id": "L7-F2", "representations": {"en": {"language": "en", "value": "cats"}}, "grammaticalFeatures": ["Q146786"], "claims": {}},
+		"L7-S1": {"type": "sense", "id": "L7-S1", "glosses": {"en": {"language": "en", "value": "domesticated animal"}}, "claims": {}}
+	}
+}`
+
+func createWikibaseLexemeServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(wikibaseLexemeTestURL, func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(wikibaseLexemeTestResponse))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestWikibaseLexemes(t *testing.T) {
+	srv := httptest.NewServer(createWikibaseLexemeServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.WikibaseURL = wikibaseLexemeTestURL
+
+	entities, err := client.WikibaseEntities(context.Background(), []string{"L7", "L7-F2", "L7-S1"})
+	assert.NoError(t, err)
+
+	lexeme := entities["L7"]
+	assert.Equal(t, WikibaseEntityLexeme, lexeme.Type)
+	assert.Equal(t, "cat", lexeme.Lemma("en"))
+	assert.Equal(t, "Q1084", lexeme.LexicalCategory)
+	assert.Equal(t, "Q1860", lexeme.Language)
+	assert.Len(t, lexeme.Forms, 2)
+
+	form, ok := lexeme.Form("L7-F2")
+	assert.True(t, ok)
+	assert.Equal(t, "cats", form.Representation("en"))
+	assert.Equal(t, []string{"Q146786"}, form.GrammaticalFeatures)
+
+	sense, ok := lexeme.Sense("L7-S1")
+	assert.True(t, ok)
+	assert.Equal(t, "domesticated animal", sense.Gloss("en"))
+	assert.Equal(t, "Q146", sense.Claims["P5137"][0].MainSnak.DataValue.EntityID.ID)
+
+	_, ok = lexeme.Sense("L7-S2")
+	assert.False(t, ok)
+
+	formEntity := entities["L7-F2"]
+	form, ok = formEntity.Form("L7-F2")
+	assert.True(t, ok)
+	assert.Equal(t, "cats", form.Representation("en"))
+
+	senseEntity := entities["L7-S1"]
+	sense, ok = senseEntity.Sense("L7-S1")
+	assert.True(t, ok)
+	assert.Equal(t, "domesticated animal", sense.Gloss("fr", "en"))
+}
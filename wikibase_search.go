@@ -0,0 +1,51 @@
+package mediawiki
+
+// WikibaseSearchOptions optional parameters for SearchEntities method.
+// Continue is the offset returned by the previous search, StrictLanguage disables language fallback.
+type WikibaseSearchOptions struct {
+	Limit          int
+	Continue       int
+	StrictLanguage bool
+	UseLang        string
+}
+
+// WikibaseSearchMatch term the search text was matched against.
+// Type is one of "label", "alias", "description", "entityId" and alike.
+type WikibaseSearchMatch struct {
+	Type     string `json:"type"`
+	Language string `json:"language"`
+	Text     string `json:"text"`
+}
+
+// WikibaseSearchDisplay label and description to show for the result.
+type WikibaseSearchDisplay struct {
+	Label       *WikibaseTerm `json:"label"`
+	Description *WikibaseTerm `json:"description"`
+}
+
+// WikibaseSearchResult single entity found by the search.
+type WikibaseSearchResult struct {
+	ID          string                `json:"id"`
+	Title       string                `json:"title"`
+	PageID      int                   `json:"pageid"`
+	ConceptURI  string                `json:"concepturi"`
+	Repository  string                `json:"repository"`
+	URL         string                `json:"url"`
+	Label       string                `json:"label"`
+	Description string                `json:"description"`
+	Aliases     []string              `json:"aliases"`
+	Display     WikibaseSearchDisplay `json:"display"`
+	Match       WikibaseSearchMatch   `json:"match"`
+}
+
+// WikibaseSearch page of search results, Continue is zero when there are no more results.
+type WikibaseSearch struct {
+	Results  []WikibaseSearchResult
+	Continue int
+}
+
+type wikibaseSearchResponse struct {
+	Search         []WikibaseSearchResult `json:"search"`
+	SearchContinue int                    `json:"search-continue"`
+	Error          *APIError              `json:"error"`
+}
@@ -0,0 +1,86 @@
+package mediawiki
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wikibaseSearchTestURL = "/wikibase-search"
+
+func createWikibaseSearchServer() http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(wikibaseSearchTestURL, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		res := ""
+
+		switch {
+		case query.Get("action") != "wbsearchentities" || query.Get("language") != "en" || query.Get("type") != "item":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		case query.Get("continue") == "":
+			res = `{
+				"searchinfo": {"search": "adams"},
+				"search": [
+					{
+						"id": "Q42",
+						"title": "Q42",
+						"pageid": 138,
+						"concepturi": "http://www.wikidata.org/entity/Q42",
+						"url": "//www.wikidata.org/wiki/Q42",
+						"display": {"label": {"value": "Douglas Adams", "language": "en"}, "description": {"value": "English writer", "language": "en"}},
+						"label": "Douglas Adams",
+						"description": "English writer",
+						"match": {"type": "alias", "language": "en", "text": "Adams"},
+						"aliases": ["Adams"]
+					}
+				],
+				"search-continue": 1,
+				"success": 1
+			}`
+		default:
+			res = `{"search": [{"id": "Q100", "match": {"type": "label", "language": "en", "text": "Adams"}}], "success": 1}`
+		}
+
+		_, err := w.Write([]byte(res))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestSearchEntities(t *testing.T) {
+	srv := httptest.NewServer(createWikibaseSearchServer())
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.WikibaseURL = wikibaseSearchTestURL
+	ctx := context.Background()
+
+	search, err := client.SearchEntities(ctx, "adams", "en", WikibaseEntityItem, WikibaseSearchOptions{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, search.Results, 1)
+	assert.Equal(t, 1, search.Continue)
+
+	result := search.Results[0]
+	assert.Equal(t, "Q42", result.ID)
+	assert.Equal(t, "alias", result.Match.Type)
+	assert.Equal(t, "Adams", result.Match.Text)
+	assert.Equal(t, "Douglas Adams", result.Display.Label.Value)
+
+	search, err = client.SearchEntities(ctx, "adams", "en", WikibaseEntityItem, WikibaseSearchOptions{Continue: search.Continue})
+	assert.NoError(t, err)
+	assert.Equal(t, "Q100", search.Results[0].ID)
+	assert.Equal(t, 0, search.Continue)
+
+	_, err = client.SearchEntities(ctx, "adams", "de", WikibaseEntityItem)
+	assert.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package mediawiki
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wikibaseTestURL = "/wikibase"
+const wikibaseTestEntity = `{
+	"type": "item",
+	"id": "%s",
+	"pageid": 100,
+	"ns": 0,
+	"title": "%s",
+	"lastrevid": 1500,
+	"modified": "2023-04-01T10:00:00Z",
+	"labels": {
+		"en": { "language": "en", "value": "Douglas Adams" },
+		"de-at": { "language": "de", "value": "Douglas Adams (de)", "for-language": "de-at" }
+	},
+	"descriptions": { "en": { "language": "en", "value": "English writer" } },
+	"aliases": { "en": [{ "language": "en", "value": "DNA" }] },
+	"sitelinks": { "enwiki": { "site": "enwiki", "title": "Douglas Adams", "badges": ["Q17437796"] } },
+	"claims": {
+		"P31": [
+			{
+				"id": "Q42$1",
+				"type": "statement",
+				"rank": "normal",
+				"mainsnak": { "snaktype": "value", "property": "P31", "datatype": "wikibase-item", "datavalue": { "type": "wikibase-entityid", "value": { "entity-type": "item", "numeric-id": 5, "id": "Q5" } } },
+				"references": [{ "hash": "abc", "snaks": { "P248": [{ "snaktype": "value", "property": "P248", "datavalue": { "type": "wikibase-entityid", "value": { "entity-type": "item", "id": "Q36578" } } }] }, "snaks-order": ["P248"] }]
+			},
+			{
+				"id": "Q42$2",
+				"type": "statement",
+				"rank": "preferred",
+				"mainsnak": { "snaktype": "somevalue", "property": "P31" }
+			},
+			{
+				"id": "Q42$3",
+				"type": "statement",
+				"rank": "deprecated",
+				"mainsnak": { "snaktype": "novalue", "property": "P31" }
+			}
+		],
+		"P569": [{
+			"id": "Q42$4",
+			"type": "statement",
+			"rank": "normal",
+			"mainsnak": { "snaktype": "value", "property": "P569", "datavalue": { "type": "time", "value": { "time": "+1952-03-00T00:00:00Z", "timezone": 0, "before": 0, "after": 0, "precision": 10, "calendarmodel": "http://www.wikidata.org/entity/Q1985727" } } },
+			"qualifiers": { "P1480": [{ "snaktype": "value", "property": "P1480", "datavalue": { "type": "string", "value": "circa" } }] },
+			"qualifiers-order": ["P1480"]
+		}],
+		"P2048": [{ "type": "statement", "rank": "normal", "mainsnak": { "snaktype": "value", "property": "P2048", "datavalue": { "type": "quantity", "value": { "amount": "+1.96", "unit": "http://www.wikidata.org/entity/Q11573" } } } }],
+		"P625": [{ "type": "statement", "rank": "normal", "mainsnak": { "snaktype": "value", "property": "P625", "datavalue": { "type": "globecoordinate", "value": { "latitude": 51.5, "longitude": -0.1, "altitude": null, "precision": 0.1, "globe": "http://www.wikidata.org/entity/Q2" } } } }],
+		"P1559": [{ "type": "statement", "rank": "normal", "mainsnak": { "snaktype": "value", "property": "P1559", "datavalue": { "type": "monolingualtext", "value": { "text": "Douglas Noël Adams", "language": "en" } } } }]
+	}
+}`
+
+func createWikibaseServer(batches *[]int) http.Handler {
+	router := http.NewServeMux()
+
+	router.HandleFunc(wikibaseTestURL, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		if r.Form.Get("action") != "wbgetentities" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ids := strings.Split(r.Form.Get("ids"), "|")
+		*batches = append(*batches, len(ids))
+		entities := []string{}
+		seen := map[string]bool{}
+
+		for _, id := range ids {
+			// the redirect target is returned once, even if requested by both ids
+			if seen[id] || (id == "Q43" && seen["Q2"]) {
+				continue
+			}
+
+			seen[id] = true
+
+			switch {
+			case id == "Q0":
+				_, _ = w.Write([]byte(`{"error": {"code": "no-such-entity", "info": "Could not find an entity with the ID \"Q0\"."}}`))
+				return
+			case id == "Q1":
+				entities = append(entities, fmt.Sprintf(`"%s": {"id": "%s", "missing": ""}`, id, id))
+			case id == "Q2":
+				entities = append(entities, fmt.Sprintf(`"Q43": %s`, strings.Replace(fmt.Sprintf(wikibaseTestEntity, "Q43", "Q43"), `"type": "item",`, `"type": "item", "redirects": {"from": "Q2", "to": "Q43"},`, 1)))
+			default:
+				entities = append(entities, fmt.Sprintf(`"%s": %s`, id, fmt.Sprintf(wikibaseTestEntity, id, id)))
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"success": 1, "entities": {` + strings.Join(entities, ",") + `}}`))
+
+		if err != nil {
+			log.Panic(err)
+		}
+	})
+
+	return router
+}
+
+func TestWikibaseEntities(t *testing.T) {
+	batches := []int{}
+	srv := httptest.NewServer(createWikibaseServer(&batches))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.WikibaseURL = wikibaseTestURL
+	ctx := context.Background()
+
+	entities, err := client.WikibaseEntities(ctx, []string{"Q42", "Q1", "Q2"}, WikibaseEntitiesOptions{
+		Languages:        []string{"en", "de-at"},
+		LanguageFallback: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entities, 4)
+	assert.True(t, bool(entities["Q1"].Missing))
+	assert.Equal(t, "Q43", entities["Q2"].ID)
+	assert.Equal(t, "Q43", entities["Q43"].ID)
+
+	entity := entities["Q42"]
+	assert.Equal(t, 1500, entity.LastRevID)
+	assert.Equal(t, "Douglas Adams (de)", entity.Label("fr", "de-at", "en"))
+	assert.Equal(t, "de", entity.Labels["de-at"].Language)
+	assert.Equal(t, "English writer", entity.Description("en"))
+	assert.Equal(t, "DNA", entity.Aliases["en"][0].Value)
+	assert.Equal(t, "Douglas Adams", entity.Sitelinks["enwiki"].Title)
+
+	statements := entity.Statements("P31")
+	assert.Len(t, statements, 2)
+	assert.Equal(t, WikibaseSnakSomeValue, statements[0].MainSnak.SnakType)
+	assert.Nil(t, statements[0].MainSnak.DataValue)
+	assert.Equal(t, "Q5", statements[1].MainSnak.DataValue.EntityID.ID)
+	assert.Equal(t, "Q36578", statements[1].References[0].Snaks["P248"][0].DataValue.EntityID.ID)
+
+	birth := entity.Claims["P569"][0]
+	assert.Equal(t, 10, birth.MainSnak.DataValue.Time.Precision)
+	assert.Equal(t, "circa", birth.Qualifiers["P1480"][0].DataValue.String)
+
+	date, err := birth.MainSnak.DataValue.Time.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1952, 3, 1, 0, 0, 0, 0, time.UTC), date)
+
+	assert.Equal(t, "+1.96", entity.Claims["P2048"][0].MainSnak.DataValue.Quantity.Amount)
+	assert.Equal(t, 51.5, entity.Claims["P625"][0].MainSnak.DataValue.GlobeCoordinate.Latitude)
+	assert.Nil(t, entity.Claims["P625"][0].MainSnak.DataValue.GlobeCoordinate.Altitude)
+	assert.Equal(t, "Douglas Noël Adams", entity.Claims["P1559"][0].MainSnak.DataValue.MonolingualText.Text)
+
+	t.Run("batches", func(t *testing.T) {
+		batches = batches[:0]
+		ids := []string{}
+
+		for i := 0; i < 120; i++ {
+			ids = append(ids, fmt.Sprintf("Q%d", 100+i))
+		}
+
+		entities, err := client.WikibaseEntities(ctx, ids)
+		assert.NoError(t, err)
+		assert.Len(t, entities, 120)
+		assert.Equal(t, []int{50, 50, 20}, batches)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		_, err := client.WikibaseEntities(ctx, []string{"Q0"})
+		apiErr := new(APIError)
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "no-such-entity", apiErr.Code)
+	})
+
+	t.Run("value roundtrip", func(t *testing.T) {
+		data, err := json.Marshal(birth.MainSnak.DataValue)
+		assert.NoError(t, err)
+
+		value := new(WikibaseDataValue)
+		assert.NoError(t, json.Unmarshal(data, value))
+		assert.Equal(t, birth.MainSnak.DataValue.Time, value.Time)
+
+		data, err = json.Marshal(birth.Qualifiers["P1480"][0].DataValue)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"type": "string", "value": "circa"}`, string(data))
+	})
+
+	t.Run("unsupported time", func(t *testing.T) {
+		_, err := (&WikibaseTimeValue{Time: "-0500-00-00T00:00:00Z"}).Value()
+		assert.True(t, errors.Is(err, ErrUnsupportedTime))
+	})
+}
+
+func TestWikibaseEntitiesRedirectAndTarget(t *testing.T) {
+	batches := []int{}
+	srv := httptest.NewServer(createWikibaseServer(&batches))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.options.WikibaseURL = wikibaseTestURL
+
+	entities, err := client.WikibaseEntities(context.Background(), []string{"Q2", "Q43"})
+	assert.NoError(t, err)
+	assert.Len(t, entities, 2)
+	assert.Equal(t, "Q43", entities["Q2"].ID)
+	assert.Equal(t, "Q43", entities["Q43"].ID)
+	assert.Equal(t, "Q2", entities["Q43"].Redirects.From)
+}
@@ -0,0 +1,448 @@
+package wikitext
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Node single element of wikitext syntax tree, String returns node exactly as it was in the source.
+type Node interface {
+	String() string
+}
+
+// Nodes sequence of sibling nodes.
+type Nodes []Node
+
+// String serialize nodes back to wikitext.
+func (nodes Nodes) String() string {
+	buf := new(strings.Builder)
+
+	for _, node := range nodes {
+		buf.WriteString(node.String())
+	}
+
+	return buf.String()
+}
+
+// Text plain text without any markup recognized by the parser.
+type Text struct {
+	Value string
+}
+
+// String serialize node back to wikitext.
+func (txt *Text) String() string {
+	return txt.Value
+}
+
+// Comment html comment ("<!-- ... -->"), unclosed comment runs to the end of the input.
+type Comment struct {
+	Value    string
+	Unclosed bool
+}
+
+// String serialize node back to wikitext.
+func (cmt *Comment) String() string {
+	if cmt.Unclosed {
+		return "<!--" + cmt.Value
+	}
+
+	return "<!--" + cmt.Value + "-->"
+}
+
+// Heading section heading ("== Title ==").
+type Heading struct {
+	Level    int
+	Title    Nodes
+	Trailing string
+}
+
+// String serialize node back to wikitext.
+func (hdg *Heading) String() string {
+	marks := strings.Repeat("=", hdg.Level)
+	return marks + hdg.Title.String() + marks + hdg.Trailing
+}
+
+// Text get heading title without surrounding whitespace.
+func (hdg *Heading) Text() string {
+	return strings.TrimSpace(hdg.Title.String())
+}
+
+// WikiLink internal link ("[[Target|text]]"), files can have more than one part after the target.
+type WikiLink struct {
+	Target Nodes
+	Parts  []Nodes
+}
+
+// String serialize node back to wikitext.
+func (lnk *WikiLink) String() string {
+	buf := new(strings.Builder)
+	buf.WriteString("[[")
+	buf.WriteString(lnk.Target.String())
+
+	for _, part := range lnk.Parts {
+		buf.WriteString("|")
+		buf.WriteString(part.String())
+	}
+
+	buf.WriteString("]]")
+	return buf.String()
+}
+
+// Title get link target title without fragment, leading colon and surrounding whitespace.
+func (lnk *WikiLink) Title() string {
+	title := strings.TrimSpace(lnk.Target.String())
+	title = strings.TrimPrefix(title, ":")
+
+	if idx := strings.Index(title, "#"); idx != -1 {
+		title = title[:idx]
+	}
+
+	return strings.TrimSpace(strings.ReplaceAll(title, "_", " "))
+}
+
+// Fragment get section fragment of the target.
+func (lnk *WikiLink) Fragment() string {
+	target := lnk.Target.String()
+
+	if idx := strings.Index(target, "#"); idx != -1 {
+		return strings.TrimSpace(target[idx+1:])
+	}
+
+	return ""
+}
+
+// Text get link text, the target for links without text.
+func (lnk *WikiLink) Text() string {
+	if len(lnk.Parts) > 0 {
+		return lnk.Parts[len(lnk.Parts)-1].String()
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(lnk.Target.String()), ":")
+}
+
+// IsCategory check if link puts the page into category, prefixes are localized
+// namespace names and default to "Category".
+func (lnk *WikiLink) IsCategory(prefixes ...string) bool {
+	return lnk.hasNamespace(prefixes, "Category")
+}
+
+// IsFile check if link embeds a file, prefixes default to "File" and "Image".
+func (lnk *WikiLink) IsFile(prefixes ...string) bool {
+	return lnk.hasNamespace(prefixes, "File", "Image")
+}
+
+func (lnk *WikiLink) hasNamespace(prefixes []string, defaults ...string) bool {
+	target := strings.TrimSpace(lnk.Target.String())
+
+	if strings.HasPrefix(target, ":") {
+		return false
+	}
+
+	if len(prefixes) == 0 {
+		prefixes = defaults
+	}
+
+	idx := strings.Index(target, ":")
+
+	if idx == -1 {
+		return false
+	}
+
+	ns := strings.TrimSpace(strings.ReplaceAll(target[:idx], "_", " "))
+
+	for _, prefix := range prefixes {
+		if strings.EqualFold(ns, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExternalLink bracketed ("[http://example.com text]") or bare ("http://example.com") external link.
+type ExternalLink struct {
+	URL       string
+	Separator string
+	Text      Nodes
+	Bare      bool
+}
+
+// String serialize node back to wikitext.
+func (lnk *ExternalLink) String() string {
+	if lnk.Bare {
+		return lnk.URL
+	}
+
+	return "[" + lnk.URL + lnk.Separator + lnk.Text.String() + "]"
+}
+
+// Param template parameter, Name is nil for positional parameters.
+type Param struct {
+	Name  Nodes
+	Value Nodes
+}
+
+// String serialize node back to wikitext (without leading pipe).
+func (prm *Param) String() string {
+	if prm.Name == nil {
+		return prm.Value.String()
+	}
+
+	return prm.Name.String() + "=" + prm.Value.String()
+}
+
+// Template template transclusion ("{{Name|positional|name=value}}").
+type Template struct {
+	Name   Nodes
+	Params []*Param
+}
+
+// String serialize node back to wikitext.
+func (tpl *Template) String() string {
+	buf := new(strings.Builder)
+	buf.WriteString("{{")
+	buf.WriteString(tpl.Name.String())
+
+	for _, prm := range tpl.Params {
+		buf.WriteString("|")
+		buf.WriteString(prm.String())
+	}
+
+	buf.WriteString("}}")
+	return buf.String()
+}
+
+// Title get template name without surrounding whitespace, with first letter upper cased.
+func (tpl *Template) Title() string {
+	title := strings.TrimSpace(strings.ReplaceAll(tpl.Name.String(), "_", " "))
+
+	if r, size := utf8.DecodeRuneInString(title); r != utf8.RuneError {
+		title = string(unicode.ToUpper(r)) + title[size:]
+	}
+
+	return title
+}
+
+// Param get parameter by name, positional parameters are named by their index starting from "1".
+func (tpl *Template) Param(name string) *Param {
+	var found *Param
+	idx := 0
+
+	for _, prm := range tpl.Params {
+		key := ""
+
+		if prm.Name == nil {
+			idx++
+			key = strconv.Itoa(idx)
+		} else {
+			key = strings.TrimSpace(prm.Name.String())
+		}
+
+		// duplicated parameters are allowed, the last one wins
+		if key == name {
+			found = prm
+		}
+	}
+
+	return found
+}
+
+// SetParam set parameter value, parameter is added to the end if it's not present.
+// Whitespace around the old value is kept to preserve formatting.
+func (tpl *Template) SetParam(name string, value string) {
+	if prm := tpl.Param(name); prm != nil {
+		old := prm.Value.String()
+		trimmed := strings.TrimSpace(old)
+		start := strings.Index(old, trimmed)
+
+		// empty value goes before the line break to keep parameters on separate lines
+		if len(trimmed) == 0 {
+			if start = strings.IndexByte(old, '\n'); start == -1 {
+				start = len(old)
+			}
+		}
+
+		prm.Value = Nodes{&Text{Value: old[:start] + value + old[start+len(trimmed):]}}
+		return
+	}
+
+	tpl.Params = append(tpl.Params, &Param{
+		Name:  Nodes{&Text{Value: name}},
+		Value: Nodes{&Text{Value: value}},
+	})
+}
+
+// ParserFunction parser function or magic word call ("{{#if:cond|then|else}}").
+type ParserFunction struct {
+	Name string
+	Args []Nodes
+}
+
+// String serialize node back to wikitext.
+func (pf *ParserFunction) String() string {
+	buf := new(strings.Builder)
+	buf.WriteString("{{")
+	buf.WriteString(pf.Name)
+	buf.WriteString(":")
+
+	for idx, arg := range pf.Args {
+		if idx > 0 {
+			buf.WriteString("|")
+		}
+
+		buf.WriteString(arg.String())
+	}
+
+	buf.WriteString("}}")
+	return buf.String()
+}
+
+// Function get function name without "#" and surrounding whitespace, lower cased.
+func (pf *ParserFunction) Function() string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(pf.Name), "#"))
+}
+
+// Argument template argument ("{{{name|default}}}").
+type Argument struct {
+	Name    Nodes
+	Default Nodes
+}
+
+// String serialize node back to wikitext.
+func (arg *Argument) String() string {
+	if arg.Default == nil {
+		return "{{{" + arg.Name.String() + "}}}"
+	}
+
+	return "{{{" + arg.Name.String() + "|" + arg.Default.String() + "}}}"
+}
+
+// Tag extension tag ("<ref name="a">...</ref>", "<nowiki>", "<gallery>").
+// Content of raw tags (nowiki, pre, gallery and alike) is not parsed and kept in Content.
+type Tag struct {
+	Name     string
+	Open     string
+	Close    string
+	Children Nodes
+	Content  string
+	Raw      bool
+}
+
+// String serialize node back to wikitext.
+func (tag *Tag) String() string {
+	if tag.Raw {
+		return tag.Open + tag.Content + tag.Close
+	}
+
+	return tag.Open + tag.Children.String() + tag.Close
+}
+
+// SelfClosing check if tag has no content ("<ref name="a" />").
+func (tag *Tag) SelfClosing() bool {
+	return len(tag.Close) == 0
+}
+
+// Attrs get tag attributes.
+func (tag *Tag) Attrs() map[string]string {
+	return parseAttrs(strings.TrimSuffix(strings.TrimSuffix(tag.Open[1+len(tag.Name):], ">"), "/"))
+}
+
+// Text get tag content as text.
+func (tag *Tag) Text() string {
+	if tag.Raw {
+		return tag.Content
+	}
+
+	return tag.Children.String()
+}
+
+// ListItem single line of a list ("* item", "# item", ": indent", "; term").
+type ListItem struct {
+	Prefix  string
+	Content Nodes
+}
+
+// String serialize node back to wikitext.
+func (itm *ListItem) String() string {
+	return itm.Prefix + itm.Content.String()
+}
+
+// Ordered check if item belongs to numbered list.
+func (itm *ListItem) Ordered() bool {
+	return strings.HasSuffix(itm.Prefix, "#")
+}
+
+// Depth get nesting level of the item.
+func (itm *ListItem) Depth() int {
+	return len(itm.Prefix)
+}
+
+func parseAttrs(src string) map[string]string {
+	attrs := map[string]string{}
+	idx := 0
+
+	for idx < len(src) {
+		for idx < len(src) && isSpace(src[idx]) {
+			idx++
+		}
+
+		start := idx
+
+		for idx < len(src) && !isSpace(src[idx]) && src[idx] != '=' {
+			idx++
+		}
+
+		name := strings.ToLower(src[start:idx])
+
+		for idx < len(src) && isSpace(src[idx]) {
+			idx++
+		}
+
+		if idx >= len(src) || src[idx] != '=' {
+			if len(name) > 0 {
+				attrs[name] = ""
+			}
+
+			continue
+		}
+
+		idx++
+
+		for idx < len(src) && isSpace(src[idx]) {
+			idx++
+		}
+
+		value := ""
+
+		if idx < len(src) && (src[idx] == '"' || src[idx] == '\'') {
+			quote := src[idx]
+			end := strings.IndexByte(src[idx+1:], quote)
+
+			if end == -1 {
+				value, idx = src[idx+1:], len(src)
+			} else {
+				value, idx = src[idx+1:idx+1+end], idx+end+2
+			}
+		} else {
+			start := idx
+
+			for idx < len(src) && !isSpace(src[idx]) {
+				idx++
+			}
+
+			value = src[start:idx]
+		}
+
+		if len(name) > 0 {
+			attrs[name] = value
+		}
+	}
+
+	return attrs
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,40 @@
+package wikitext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateSetParam(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("before {{Infobox\n| name = Old\n| empty =\n}} after")
+	tpl := doc.Templates()[0]
+
+	tpl.SetParam("name", "New")
+	tpl.SetParam("empty", "Filled")
+	tpl.SetParam("added", "Value")
+
+	assert.Equal("before {{Infobox\n| name = New\n| empty =Filled\n|added=Value}} after", doc.String())
+}
+
+func TestWikiLinkNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	lnks := ParseString("[[Kategorie:Japan]] [[:Category:Japan]] [[image:a.png|thumb]]").WikiLinks()
+
+	assert.False(lnks[0].IsCategory())
+	assert.True(lnks[0].IsCategory("Kategorie", "Category"))
+	assert.False(lnks[1].IsCategory())
+	assert.True(lnks[2].IsFile())
+	assert.Equal("thumb", lnks[2].Text())
+}
+
+func TestTagAttrs(t *testing.T) {
+	attrs := ParseString(`<ref name=plain group='quoted' follow="with space" />`).Refs()[0].Attrs()
+
+	assert.Equal(t, map[string]string{
+		"name":   "plain",
+		"group":  "quoted",
+		"follow": "with space",
+	}, attrs)
+}
@@ -0,0 +1,241 @@
+// Package wikitext parses wikitext into lossless syntax tree, serializing unmodified tree gives back the source.
+package wikitext
+
+// Document parsed wikitext.
+type Document struct {
+	Nodes Nodes
+}
+
+// Parse parse wikitext into the document.
+func Parse(text []byte) *Document {
+	return ParseString(string(text))
+}
+
+// ParseString parse wikitext string into the document.
+func ParseString(text string) *Document {
+	nodes, _ := newParser(text, true, 0).parseNodes(0, nil, true)
+	return &Document{Nodes: nodes}
+}
+
+// String serialize document back to wikitext.
+func (doc *Document) String() string {
+	return doc.Nodes.String()
+}
+
+// Walk visit nodes and all of their descendants in depth first order,
+// children are skipped if visit function returns false.
+func Walk(nodes Nodes, visit func(Node) bool) {
+	for _, node := range nodes {
+		if !visit(node) {
+			continue
+		}
+
+		for _, children := range Children(node) {
+			Walk(children, visit)
+		}
+	}
+}
+
+// Children get child node lists of the node.
+func Children(node Node) []Nodes {
+	switch node := node.(type) {
+	case *Heading:
+		return []Nodes{node.Title}
+	case *WikiLink:
+		return append([]Nodes{node.Target}, node.Parts...)
+	case *ExternalLink:
+		return []Nodes{node.Text}
+	case *Template:
+		children := []Nodes{node.Name}
+
+		for _, prm := range node.Params {
+			children = append(children, prm.Name, prm.Value)
+		}
+
+		return children
+	case *ParserFunction:
+		return node.Args
+	case *Argument:
+		return []Nodes{node.Name, node.Default}
+	case *Tag:
+		return []Nodes{node.Children}
+	case *ListItem:
+		return []Nodes{node.Content}
+	case *Table:
+		return []Nodes{node.Attrs, node.Items}
+	case *TableRow:
+		return []Nodes{node.Attrs}
+	case *TableCaption:
+		return []Nodes{node.Attrs, node.Content}
+	case *TableCell:
+		return []Nodes{node.Attrs, node.Content}
+	}
+
+	return nil
+}
+
+// Templates get all templates including nested ones.
+func (doc *Document) Templates() []*Template {
+	tpls := []*Template{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if tpl, ok := node.(*Template); ok {
+			tpls = append(tpls, tpl)
+		}
+
+		return true
+	})
+
+	return tpls
+}
+
+// ParserFunctions get all parser functions including nested ones.
+func (doc *Document) ParserFunctions() []*ParserFunction {
+	pfs := []*ParserFunction{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if pf, ok := node.(*ParserFunction); ok {
+			pfs = append(pfs, pf)
+		}
+
+		return true
+	})
+
+	return pfs
+}
+
+// WikiLinks get all internal links including categories and files.
+func (doc *Document) WikiLinks() []*WikiLink {
+	lnks := []*WikiLink{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if lnk, ok := node.(*WikiLink); ok {
+			lnks = append(lnks, lnk)
+		}
+
+		return true
+	})
+
+	return lnks
+}
+
+// Categories get category links, prefixes are localized category namespace names.
+func (doc *Document) Categories(prefixes ...string) []*WikiLink {
+	cats := []*WikiLink{}
+
+	for _, lnk := range doc.WikiLinks() {
+		if lnk.IsCategory(prefixes...) {
+			cats = append(cats, lnk)
+		}
+	}
+
+	return cats
+}
+
+// ExternalLinks get all external links.
+func (doc *Document) ExternalLinks() []*ExternalLink {
+	lnks := []*ExternalLink{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if lnk, ok := node.(*ExternalLink); ok {
+			lnks = append(lnks, lnk)
+		}
+
+		return true
+	})
+
+	return lnks
+}
+
+// Headings get all section headings.
+func (doc *Document) Headings() []*Heading {
+	hdgs := []*Heading{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if hdg, ok := node.(*Heading); ok {
+			hdgs = append(hdgs, hdg)
+		}
+
+		return true
+	})
+
+	return hdgs
+}
+
+// Tags get extension tags with the names, all tags if no names provided.
+func (doc *Document) Tags(names ...string) []*Tag {
+	tags := []*Tag{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		tag, ok := node.(*Tag)
+
+		if !ok {
+			return true
+		}
+
+		if len(names) == 0 {
+			tags = append(tags, tag)
+		}
+
+		for _, name := range names {
+			if tag.Name == name {
+				tags = append(tags, tag)
+				break
+			}
+		}
+
+		return true
+	})
+
+	return tags
+}
+
+// Refs get all "<ref>" tags.
+func (doc *Document) Refs() []*Tag {
+	return doc.Tags("ref")
+}
+
+// Tables get all tables including nested ones.
+func (doc *Document) Tables() []*Table {
+	tbls := []*Table{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if tbl, ok := node.(*Table); ok {
+			tbls = append(tbls, tbl)
+		}
+
+		return true
+	})
+
+	return tbls
+}
+
+// ListItems get all list items.
+func (doc *Document) ListItems() []*ListItem {
+	itms := []*ListItem{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if itm, ok := node.(*ListItem); ok {
+			itms = append(itms, itm)
+		}
+
+		return true
+	})
+
+	return itms
+}
+
+// Comments get all html comments.
+func (doc *Document) Comments() []*Comment {
+	cmts := []*Comment{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if cmt, ok := node.(*Comment); ok {
+			cmts = append(cmts, cmt)
+		}
+
+		return true
+	})
+
+	return cmts
+}
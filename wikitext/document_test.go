@@ -0,0 +1,138 @@
+package wikitext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const documentTestWikitext = `{{Infobox person
+| name       = Hattori Hanzō
+| birth_date = {{birth year|1542}}
+| image      = [[File:Hanzo.jpg|thumb|Portrait of [[Hattori Hanzō]]]]
+}}
+A '''ninja''' was a covert agent in [[feudal Japan|Japan]].<ref name="turnbull">{{cite book |last=Turnbull |first=Stephen |title=Ninja |isbn=978-1-84176-525-9}}</ref> See [https://example.org/ninja external source] or https://example.org/bare.
+<!-- hidden comment -->
+== History ==
+{{#if:{{{1|}}}|yes|no}} {{DISPLAYTITLE:''Ninja''}}
+* first item
+** nested item with <nowiki>[[not a link]]</nowiki>
+# numbered
+; term : definition
+
+=== Origins ===
+{| class="wikitable"
+|+ Clans
+|-
+! Clan !! Province
+|-
+| rowspan="2" | Iga || Iga
+|-
+| Koga
+|}
+<gallery>
+File:A.jpg|Caption
+</gallery>
+<ref name="turnbull" />
+[[Category:Japanese warriors|Ninja]]
+[[:Category:Not a category]]
+`
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString(documentTestWikitext)
+
+	assert.Equal(documentTestWikitext, doc.String())
+	assert.Equal(documentTestWikitext, Parse([]byte(documentTestWikitext)).String())
+
+	tpls := doc.Templates()
+	assert.Len(tpls, 3)
+	assert.Equal("Infobox person", tpls[0].Title())
+	assert.Equal("Birth year", tpls[1].Title())
+	assert.Equal("Cite book", tpls[2].Title())
+
+	pfs := doc.ParserFunctions()
+	assert.Len(pfs, 2)
+	assert.Equal("if", pfs[0].Function())
+	assert.Equal("displaytitle", pfs[1].Function())
+
+	lnks := doc.WikiLinks()
+	assert.Len(lnks, 5)
+	assert.Equal("File:Hanzo.jpg", lnks[0].Title())
+	assert.True(lnks[0].IsFile())
+	assert.Equal("Hattori Hanzō", lnks[1].Title())
+	assert.Equal("feudal Japan", lnks[2].Title())
+	assert.Equal("Japan", lnks[2].Text())
+
+	cats := doc.Categories()
+	assert.Len(cats, 1)
+	assert.Equal("Category:Japanese warriors", cats[0].Title())
+
+	extlnks := doc.ExternalLinks()
+	assert.Len(extlnks, 2)
+	assert.Equal("https://example.org/ninja", extlnks[0].URL)
+	assert.Equal("external source", extlnks[0].Text.String())
+	assert.Equal("https://example.org/bare", extlnks[1].URL)
+	assert.True(extlnks[1].Bare)
+
+	hdgs := doc.Headings()
+	assert.Len(hdgs, 2)
+	assert.Equal("History", hdgs[0].Text())
+	assert.Equal(2, hdgs[0].Level)
+	assert.Equal(3, hdgs[1].Level)
+
+	refs := doc.Refs()
+	assert.Len(refs, 2)
+	assert.Equal("turnbull", refs[0].Attrs()["name"])
+	assert.False(refs[0].SelfClosing())
+	assert.True(refs[1].SelfClosing())
+
+	assert.Len(doc.Tags("nowiki", "gallery"), 2)
+	assert.Len(doc.Tags(), 4)
+	assert.Len(doc.Tables(), 1)
+	assert.Len(doc.ListItems(), 4)
+	assert.Len(doc.Comments(), 1)
+	assert.Equal(" hidden comment ", doc.Comments()[0].Value)
+}
+
+func TestWalk(t *testing.T) {
+	doc := ParseString("{{a|{{b}}}} [[c|{{d}}]]")
+	names := []string{}
+
+	Walk(doc.Nodes, func(node Node) bool {
+		if tpl, ok := node.(*Template); ok {
+			names = append(names, tpl.Title())
+			return tpl.Title() != "A"
+		}
+
+		return true
+	})
+
+	assert.Equal(t, []string{"A", "D"}, names)
+}
+
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		documentTestWikitext,
+		"{{{{{{a}}}}}}",
+		"[[a|[[b]]]]",
+		"{|\n|a||b\n|-\n!c!!d\n|}",
+		"<ref>unclosed",
+		"== a ==\n=b=",
+		"<!-- unclosed",
+		"[http://a [[b]]]",
+		"{{a|b=c|d}}}}",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		doc := ParseString(text)
+
+		if doc.String() != text {
+			t.Fatalf("parsed document doesn't match the source: %q", text)
+		}
+	})
+}
@@ -0,0 +1,726 @@
+package wikitext
+
+import (
+	"strings"
+)
+
+// maxDepth limits nesting of the constructs, deeper markup is kept as text.
+const maxDepth = 100
+
+// stepsPerByte limits amount of backtracking on malformed input, when parser runs out of
+// steps the rest of the markup is kept as text to guarantee linear time.
+const stepsPerByte = 16
+
+var rawTags = map[string]bool{
+	"nowiki":          true,
+	"pre":             true,
+	"math":            true,
+	"chem":            true,
+	"ce":              true,
+	"syntaxhighlight": true,
+	"source":          true,
+	"score":           true,
+	"timeline":        true,
+	"templatedata":    true,
+	"templatestyles":  true,
+	"graph":           true,
+	"mapframe":        true,
+	"maplink":         true,
+	"hiero":           true,
+	"gallery":         true,
+	"imagemap":        true,
+	"inputbox":        true,
+	"categorytree":    true,
+	"charinsert":      true,
+}
+
+var parsedTags = map[string]bool{
+	"ref":         true,
+	"references":  true,
+	"poem":        true,
+	"includeonly": true,
+	"noinclude":   true,
+	"onlyinclude": true,
+	"indicator":   true,
+	"section":     true,
+}
+
+var functions = map[string]bool{
+	"lc":                  true,
+	"uc":                  true,
+	"lcfirst":             true,
+	"ucfirst":             true,
+	"urlencode":           true,
+	"anchorencode":        true,
+	"fullurl":             true,
+	"fullurle":            true,
+	"localurl":            true,
+	"localurle":           true,
+	"canonicalurl":        true,
+	"canonicalurle":       true,
+	"filepath":            true,
+	"formatnum":           true,
+	"formatdate":          true,
+	"padleft":             true,
+	"padright":            true,
+	"plural":              true,
+	"grammar":             true,
+	"gender":              true,
+	"int":                 true,
+	"ns":                  true,
+	"nse":                 true,
+	"bidi":                true,
+	"displaytitle":        true,
+	"defaultsort":         true,
+	"defaultsortkey":      true,
+	"defaultcategorysort": true,
+	"pagesincategory":     true,
+	"pagesize":            true,
+	"protectionlevel":     true,
+	"numberingroup":       true,
+	"special":             true,
+	"speciale":            true,
+	"tag":                 true,
+}
+
+var urlSchemes = []string{"https://", "http://", "ftp://", "ftps://", "irc://", "ircs://", "news:", "mailto:", "//"}
+
+var bareURLSchemes = []string{"https://", "http://", "ftp://"}
+
+type stopFunc func(src string, pos int) bool
+
+type failure struct {
+	kind byte
+	pos  int
+}
+
+// strongStops closing markup of the constructs that terminates everything nested inside.
+var strongStops = []string{"}}}", "}}", "]]"}
+
+type parser struct {
+	src       string
+	lower     string
+	lineStart bool
+	depth     int
+	braces    int
+	strong    [3]int
+	steps     int
+	last      map[string]int
+	unclosed  map[string]int
+	failed    map[failure]bool
+}
+
+func newParser(src string, lineStart bool, depth int) *parser {
+	return &parser{
+		src:       src,
+		lower:     asciiLower(src),
+		lineStart: lineStart,
+		depth:     depth,
+		steps:     stepsPerByte*len(src) + 1024,
+		last:      map[string]int{},
+		unclosed:  map[string]int{},
+		failed:    map[failure]bool{},
+	}
+}
+
+// parseNodes parse nodes starting from pos until stop condition, strong stop of enclosing construct or end of input.
+func (p *parser) parseNodes(pos int, stop stopFunc, block bool) (Nodes, int) {
+	nodes := Nodes{}
+	text := pos
+
+	for pos < len(p.src) {
+		if (stop != nil && stop(p.src, pos)) || p.isStrongStop(pos) {
+			break
+		}
+
+		p.steps--
+
+		node, end, ok := p.parseNode(pos, block)
+
+		if !ok {
+			pos++
+			continue
+		}
+
+		if pos > text {
+			nodes = append(nodes, &Text{Value: p.src[text:pos]})
+		}
+
+		nodes = append(nodes, node)
+		pos, text = end, end
+	}
+
+	if pos > text {
+		nodes = append(nodes, &Text{Value: p.src[text:pos]})
+	}
+
+	return nodes, pos
+}
+
+func (p *parser) parseNode(pos int, block bool) (Node, int, bool) {
+	if block && p.isLineStart(pos) {
+		switch p.src[pos] {
+		case '=':
+			if node, end, ok := p.parseHeading(pos); ok {
+				return node, end, true
+			}
+		case '*', '#', ':', ';':
+			return p.parseListItem(pos)
+		}
+	}
+
+	switch p.src[pos] {
+	case '<':
+		if strings.HasPrefix(p.src[pos:], "<!--") {
+			return p.parseComment(pos)
+		}
+
+		return p.parseTag(pos)
+	case '{':
+		if block && p.braces == 0 && strings.HasPrefix(p.src[pos:], "{|") && p.isIndentedLineStart(pos) {
+			return p.parseTable(pos)
+		}
+
+		if strings.HasPrefix(p.src[pos:], "{{{") {
+			if node, end, ok := p.parseArgument(pos); ok {
+				return node, end, true
+			}
+		}
+
+		if strings.HasPrefix(p.src[pos:], "{{") {
+			return p.parseTemplate(pos)
+		}
+	case '[':
+		if strings.HasPrefix(p.src[pos:], "[[") {
+			if node, end, ok := p.parseWikiLink(pos); ok {
+				return node, end, true
+			}
+		}
+
+		return p.parseExternalLink(pos)
+	case 'h', 'H', 'f', 'F':
+		return p.parseBareURL(pos)
+	}
+
+	return nil, pos, false
+}
+
+func (p *parser) isLineStart(pos int) bool {
+	if pos == 0 {
+		return p.lineStart
+	}
+
+	return p.src[pos-1] == '\n'
+}
+
+func (p *parser) isIndentedLineStart(pos int) bool {
+	for pos > 0 && (p.src[pos-1] == ' ' || p.src[pos-1] == '\t') {
+		pos--
+	}
+
+	return p.isLineStart(pos)
+}
+
+func (p *parser) isStrongStop(pos int) bool {
+	for idx, stop := range strongStops {
+		if p.strong[idx] > 0 && strings.HasPrefix(p.src[pos:], stop) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAfter check if the closing markup is present anywhere after pos.
+func (p *parser) hasAfter(close string, pos int) bool {
+	last, ok := p.last[close]
+
+	if !ok {
+		last = strings.LastIndex(p.src, close)
+		p.last[close] = last
+	}
+
+	return last >= pos
+}
+
+// enter check depth and failure cache before parsing the construct, leave must be called after enter succeeded.
+func (p *parser) enter(kind byte, pos int, strong int) bool {
+	if p.depth >= maxDepth || p.steps <= 0 || p.failed[failure{kind, pos}] {
+		return false
+	}
+
+	p.depth++
+
+	if strong >= 0 {
+		p.strong[strong]++
+	}
+
+	return true
+}
+
+func (p *parser) leave(kind byte, pos int, strong int, ok bool) {
+	p.depth--
+
+	if strong >= 0 {
+		p.strong[strong]--
+	}
+
+	if !ok {
+		p.failed[failure{kind, pos}] = true
+	}
+}
+
+func (p *parser) parseComment(pos int) (Node, int, bool) {
+	start := pos + len("<!--")
+	end := strings.Index(p.src[start:], "-->")
+
+	if end == -1 {
+		return &Comment{Value: p.src[start:], Unclosed: true}, len(p.src), true
+	}
+
+	return &Comment{Value: p.src[start : start+end]}, start + end + len("-->"), true
+}
+
+func (p *parser) parseTag(pos int) (node Node, end int, ok bool) {
+	nameEnd := pos + 1
+
+	for nameEnd < len(p.src) && isLetter(p.lower[nameEnd]) {
+		nameEnd++
+	}
+
+	name := p.lower[pos+1 : nameEnd]
+
+	if !rawTags[name] && !parsedTags[name] {
+		return nil, pos, false
+	}
+
+	if nameEnd < len(p.src) && !isSpace(p.src[nameEnd]) && p.src[nameEnd] != '>' && p.src[nameEnd] != '/' {
+		return nil, pos, false
+	}
+
+	if !p.enter('G', pos, -1) {
+		return nil, pos, false
+	}
+
+	defer func() { p.leave('G', pos, -1, ok) }()
+
+	openEnd := strings.IndexByte(p.src[nameEnd:], '>')
+
+	if openEnd == -1 {
+		return nil, pos, false
+	}
+
+	openEnd += nameEnd + 1
+	tag := &Tag{
+		Name: name,
+		Open: p.src[pos:openEnd],
+		Raw:  rawTags[name],
+	}
+
+	if strings.HasSuffix(tag.Open, "/>") {
+		return tag, openEnd, true
+	}
+
+	closeStart, closeEnd := p.findCloseTag(name, openEnd)
+
+	if closeStart == -1 {
+		return nil, pos, false
+	}
+
+	tag.Close = p.src[closeStart:closeEnd]
+
+	if tag.Raw {
+		tag.Content = p.src[openEnd:closeStart]
+	} else {
+		sub := newParser(p.src[openEnd:closeStart], false, p.depth)
+		tag.Children, _ = sub.parseNodes(0, nil, true)
+	}
+
+	return tag, closeEnd, true
+}
+
+// findCloseTag find first "</name>" after pos, closing tags may have whitespace before ">".
+func (p *parser) findCloseTag(name string, pos int) (int, int) {
+	prefix := "</" + name
+
+	// there is no closing tag after the position where the search failed before
+	if from, ok := p.unclosed[name]; ok && pos >= from {
+		return -1, -1
+	}
+
+	for {
+		idx := strings.Index(p.lower[pos:], prefix)
+
+		if idx == -1 {
+			p.unclosed[name] = pos
+			return -1, -1
+		}
+
+		start := pos + idx
+		end := start + len(prefix)
+
+		for end < len(p.src) && isSpace(p.src[end]) {
+			end++
+		}
+
+		if end < len(p.src) && p.src[end] == '>' {
+			return start, end + 1
+		}
+
+		pos = start + len(prefix)
+	}
+}
+
+func (p *parser) parseHeading(pos int) (Node, int, bool) {
+	lineEnd := strings.IndexByte(p.src[pos:], '\n')
+
+	if lineEnd == -1 {
+		lineEnd = len(p.src)
+	} else {
+		lineEnd += pos
+	}
+
+	for idx, stop := range strongStops {
+		if p.strong[idx] == 0 {
+			continue
+		}
+
+		if end := strings.Index(p.src[pos:lineEnd], stop); end != -1 {
+			lineEnd = pos + end
+		}
+	}
+
+	line := p.src[pos:lineEnd]
+	body := strings.TrimRight(line, " \t")
+	open := len(body) - len(strings.TrimLeft(body, "="))
+	close := len(body) - len(strings.TrimRight(body, "="))
+	level := open
+
+	if close < level {
+		level = close
+	}
+
+	if level > 6 {
+		level = 6
+	}
+
+	for level > 0 && 2*level >= len(body) {
+		level--
+	}
+
+	if level == 0 || p.depth >= maxDepth || p.steps <= 0 {
+		return nil, pos, false
+	}
+
+	sub := newParser(body[level:len(body)-level], false, p.depth+1)
+	title, _ := sub.parseNodes(0, nil, false)
+
+	return &Heading{
+		Level:    level,
+		Title:    title,
+		Trailing: line[len(body):],
+	}, lineEnd, true
+}
+
+func (p *parser) parseListItem(pos int) (Node, int, bool) {
+	prefixEnd := pos
+
+	for prefixEnd < len(p.src) && strings.IndexByte("*#:;", p.src[prefixEnd]) != -1 {
+		prefixEnd++
+	}
+
+	content, end := p.parseNodes(prefixEnd, stopAt("\n"), false)
+
+	return &ListItem{
+		Prefix:  p.src[pos:prefixEnd],
+		Content: content,
+	}, end, true
+}
+
+func (p *parser) parseTemplate(pos int) (node Node, end int, ok bool) {
+	if !p.hasAfter("}}", pos+2) || !p.enter('T', pos, 1) {
+		return nil, pos, false
+	}
+
+	p.braces++
+
+	defer func() {
+		p.braces--
+		p.leave('T', pos, 1, ok)
+	}()
+
+	segments := []Nodes{}
+	end = pos + 2
+
+	for {
+		nodes, stop := p.parseNodes(end, stopAt("|", "}}"), true)
+		segments = append(segments, nodes)
+
+		if strings.HasPrefix(p.src[stop:], "}}") {
+			end = stop + 2
+			break
+		}
+
+		if !strings.HasPrefix(p.src[stop:], "|") {
+			return nil, pos, false
+		}
+
+		end = stop + 1
+	}
+
+	if pf := newParserFunction(segments); pf != nil {
+		return pf, end, true
+	}
+
+	tpl := &Template{Name: segments[0]}
+
+	for _, segment := range segments[1:] {
+		tpl.Params = append(tpl.Params, newParam(segment))
+	}
+
+	return tpl, end, true
+}
+
+func newParserFunction(segments []Nodes) *ParserFunction {
+	if len(segments[0]) == 0 {
+		return nil
+	}
+
+	text, ok := segments[0][0].(*Text)
+
+	if !ok {
+		return nil
+	}
+
+	idx := strings.IndexByte(text.Value, ':')
+
+	if idx == -1 {
+		return nil
+	}
+
+	name := text.Value[:idx]
+	trimmed := strings.ToLower(strings.TrimSpace(name))
+
+	if !strings.HasPrefix(trimmed, "#") && !functions[trimmed] {
+		return nil
+	}
+
+	first := Nodes{}
+
+	if rest := text.Value[idx+1:]; len(rest) > 0 {
+		first = append(first, &Text{Value: rest})
+	}
+
+	pf := &ParserFunction{
+		Name: name,
+		Args: []Nodes{append(first, segments[0][1:]...)},
+	}
+
+	pf.Args = append(pf.Args, segments[1:]...)
+	return pf
+}
+
+func newParam(nodes Nodes) *Param {
+	for idx, node := range nodes {
+		text, ok := node.(*Text)
+
+		if !ok {
+			continue
+		}
+
+		eq := strings.IndexByte(text.Value, '=')
+
+		if eq == -1 {
+			continue
+		}
+
+		name := append(Nodes{}, nodes[:idx]...)
+		value := Nodes{}
+
+		if eq > 0 {
+			name = append(name, &Text{Value: text.Value[:eq]})
+		}
+
+		if eq < len(text.Value)-1 {
+			value = append(value, &Text{Value: text.Value[eq+1:]})
+		}
+
+		return &Param{
+			Name:  name,
+			Value: append(value, nodes[idx+1:]...),
+		}
+	}
+
+	return &Param{Value: nodes}
+}
+
+func (p *parser) parseArgument(pos int) (node Node, end int, ok bool) {
+	if !p.hasAfter("}}}", pos+3) || !p.enter('A', pos, 0) {
+		return nil, pos, false
+	}
+
+	p.braces++
+
+	defer func() {
+		p.braces--
+		p.leave('A', pos, 0, ok)
+	}()
+
+	arg := new(Argument)
+	arg.Name, end = p.parseNodes(pos+3, stopAt("|", "}}}"), true)
+
+	if strings.HasPrefix(p.src[end:], "|") {
+		arg.Default, end = p.parseNodes(end+1, stopAt("}}}"), true)
+	}
+
+	if !strings.HasPrefix(p.src[end:], "}}}") {
+		return nil, pos, false
+	}
+
+	return arg, end + 3, true
+}
+
+func (p *parser) parseWikiLink(pos int) (node Node, end int, ok bool) {
+	if !p.hasAfter("]]", pos+2) || !p.enter('L', pos, 2) {
+		return nil, pos, false
+	}
+
+	defer func() { p.leave('L', pos, 2, ok) }()
+
+	lnk := new(WikiLink)
+	stop := stopAt("|", "]]", "\n")
+	lnk.Target, end = p.parseNodes(pos+2, stop, false)
+
+	if len(strings.TrimSpace(lnk.Target.String())) == 0 {
+		return nil, pos, false
+	}
+
+	for strings.HasPrefix(p.src[end:], "|") {
+		var part Nodes
+		part, end = p.parseNodes(end+1, stop, false)
+		lnk.Parts = append(lnk.Parts, part)
+	}
+
+	if !strings.HasPrefix(p.src[end:], "]]") {
+		return nil, pos, false
+	}
+
+	return lnk, end + 2, true
+}
+
+func (p *parser) parseExternalLink(pos int) (node Node, end int, ok bool) {
+	scheme := hasScheme(p.lower[pos+1:], urlSchemes)
+
+	if len(scheme) == 0 {
+		return nil, pos, false
+	}
+
+	if !p.hasAfter("]", pos+1) || !p.enter('E', pos, -1) {
+		return nil, pos, false
+	}
+
+	defer func() { p.leave('E', pos, -1, ok) }()
+
+	urlEnd := pos + 1 + len(scheme)
+
+	for urlEnd < len(p.src) && !isURLEnd(p.src[urlEnd]) {
+		urlEnd++
+	}
+
+	if urlEnd == pos+1+len(scheme) {
+		return nil, pos, false
+	}
+
+	lnk := &ExternalLink{URL: p.src[pos+1 : urlEnd]}
+	sepEnd := urlEnd
+
+	for sepEnd < len(p.src) && (p.src[sepEnd] == ' ' || p.src[sepEnd] == '\t') {
+		sepEnd++
+	}
+
+	lnk.Separator = p.src[urlEnd:sepEnd]
+	lnk.Text, end = p.parseNodes(sepEnd, stopAt("]", "\n"), false)
+
+	if !strings.HasPrefix(p.src[end:], "]") {
+		return nil, pos, false
+	}
+
+	return lnk, end + 1, true
+}
+
+func (p *parser) parseBareURL(pos int) (Node, int, bool) {
+	if pos > 0 && (isLetter(p.lower[pos-1]) || isDigit(p.src[pos-1])) {
+		return nil, pos, false
+	}
+
+	scheme := hasScheme(p.lower[pos:], bareURLSchemes)
+
+	if len(scheme) == 0 {
+		return nil, pos, false
+	}
+
+	end := pos + len(scheme)
+
+	for end < len(p.src) && !isURLEnd(p.src[end]) && p.src[end] != '|' && !p.isStrongStop(end) {
+		end++
+	}
+
+	url := strings.TrimRight(p.src[pos:end], ".,;:!?'")
+
+	if strings.HasSuffix(url, ")") && !strings.Contains(url, "(") {
+		url = strings.TrimRight(url, ")")
+	}
+
+	if len(url) <= len(scheme) {
+		return nil, pos, false
+	}
+
+	return &ExternalLink{URL: url, Bare: true}, pos + len(url), true
+}
+
+func stopAt(stops ...string) stopFunc {
+	return func(src string, pos int) bool {
+		for _, stop := range stops {
+			if strings.HasPrefix(src[pos:], stop) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func hasScheme(src string, schemes []string) string {
+	for _, scheme := range schemes {
+		if strings.HasPrefix(src, scheme) {
+			return scheme
+		}
+	}
+
+	return ""
+}
+
+func isURLEnd(c byte) bool {
+	return isSpace(c) || strings.IndexByte("[]<>\"{}", c) != -1
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// asciiLower lower case ascii letters only, keeping byte offsets the same as in the source.
+func asciiLower(src string) string {
+	buf := []byte(src)
+
+	for idx, c := range buf {
+		if c >= 'A' && c <= 'Z' {
+			buf[idx] = c + 'a' - 'A'
+		}
+	}
+
+	return string(buf)
+}
@@ -0,0 +1,119 @@
+package wikitext
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplate(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("{{cite web |url=http://example.org |title=A=B |{{nested|x}} | positional }}")
+
+	assert.Len(doc.Nodes, 1)
+	tpl, ok := doc.Nodes[0].(*Template)
+	assert.True(ok)
+	assert.Equal("Cite web", tpl.Title())
+	assert.Len(tpl.Params, 4)
+	assert.Equal("A=B ", tpl.Param("title").Value.String())
+	assert.Nil(tpl.Params[2].Name)
+	assert.IsType(&Template{}, tpl.Params[2].Value[0])
+	assert.Equal(" positional ", tpl.Param("2").Value.String())
+	assert.Nil(tpl.Param("3"))
+}
+
+func TestParseParserFunction(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("{{#switch: {{{1}}} | a = A | #default = B}}")
+
+	pf, ok := doc.Nodes[0].(*ParserFunction)
+	assert.True(ok)
+	assert.Equal("switch", pf.Function())
+	assert.Len(pf.Args, 3)
+	assert.IsType(&Argument{}, pf.Args[0][1])
+
+	_, ok = ParseString("{{Template:Foo|bar}}").Nodes[0].(*Template)
+	assert.True(ok)
+}
+
+func TestParseArgument(t *testing.T) {
+	assert := assert.New(t)
+
+	arg, ok := ParseString("{{{name|{{default}}}}}").Nodes[0].(*Argument)
+	assert.True(ok)
+	assert.Equal("name", arg.Name.String())
+	assert.Equal("{{default}}", arg.Default.String())
+
+	arg, ok = ParseString("{{{1}}}").Nodes[0].(*Argument)
+	assert.True(ok)
+	assert.Nil(arg.Default)
+}
+
+func TestParseHeading(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("=== Title ==  \ntext == not heading ==\n====")
+
+	hdgs := doc.Headings()
+	assert.Len(hdgs, 2)
+	assert.Equal(2, hdgs[0].Level)
+	assert.Equal("= Title", hdgs[0].Text())
+	assert.Equal("  ", hdgs[0].Trailing)
+	assert.Equal(1, hdgs[1].Level)
+	assert.Equal("==", hdgs[1].Title.String())
+}
+
+func TestParseLinks(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("[[a|b]] [[c#d]] [[unclosed [https://e.org f] [not a link] [[\nnot]]")
+
+	lnks := doc.WikiLinks()
+	assert.Len(lnks, 2)
+	assert.Equal("c", lnks[1].Title())
+	assert.Equal("d", lnks[1].Fragment())
+	assert.Equal("c#d", lnks[1].Text())
+
+	extlnks := doc.ExternalLinks()
+	assert.Len(extlnks, 1)
+	assert.Equal("https://e.org", extlnks[0].URL)
+}
+
+func TestParseTags(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString(`<REF group="note">a {{b}}</ref ><nowiki>{{c}}</nowiki><ref>unclosed`)
+
+	tags := doc.Tags()
+	assert.Len(tags, 2)
+	assert.Equal("ref", tags[0].Name)
+	assert.Equal("note", tags[0].Attrs()["group"])
+	assert.Len(doc.Templates(), 1)
+	assert.True(tags[1].Raw)
+	assert.Equal("{{c}}", tags[1].Text())
+}
+
+func TestParseListItems(t *testing.T) {
+	assert := assert.New(t)
+	itms := ParseString("*# a {{b|\nc}}\n: d").ListItems()
+
+	assert.Len(itms, 2)
+	assert.Equal(2, itms[0].Depth())
+	assert.True(itms[0].Ordered())
+	assert.Len(itms[0].Content, 2)
+	assert.False(itms[1].Ordered())
+}
+
+func TestParseMalformed(t *testing.T) {
+	inputs := []string{
+		strings.Repeat("{{a|[[b|", 4000) + "]]}}}",
+		strings.Repeat("{{{", 4000) + "}}}",
+		strings.Repeat("<ref>", 4000),
+		strings.Repeat("{|\n|{{a\n", 4000),
+	}
+
+	for _, input := range inputs {
+		start := time.Now()
+		assert.Equal(t, input, ParseString(input).String())
+		assert.Less(t, int64(time.Since(start)), int64(time.Second*5))
+	}
+}
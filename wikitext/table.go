@@ -0,0 +1,238 @@
+package wikitext
+
+import "strings"
+
+// Table wikitext table ("{| ... |}"). Items keep captions, row separators, cells and
+// the text between them in source order, use Rows to get cells grouped into rows.
+type Table struct {
+	Attrs Nodes
+	Items Nodes
+	Close string
+}
+
+// String serialize node back to wikitext.
+func (tbl *Table) String() string {
+	return "{|" + tbl.Attrs.String() + tbl.Items.String() + tbl.Close
+}
+
+// Closed check if table has closing "|}".
+func (tbl *Table) Closed() bool {
+	return len(tbl.Close) > 0
+}
+
+// Caption get table caption, nil if there is none.
+func (tbl *Table) Caption() *TableCaption {
+	for _, item := range tbl.Items {
+		if caption, ok := item.(*TableCaption); ok {
+			return caption
+		}
+	}
+
+	return nil
+}
+
+// Rows get table cells grouped into rows, cells before first row separator form implicit first row.
+func (tbl *Table) Rows() [][]*TableCell {
+	rows := [][]*TableCell{}
+	var row []*TableCell
+
+	for _, item := range tbl.Items {
+		switch item := item.(type) {
+		case *TableRow:
+			if row != nil {
+				rows = append(rows, row)
+			}
+
+			row = []*TableCell{}
+		case *TableCell:
+			row = append(row, item)
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// TableRow row separator ("|-").
+type TableRow struct {
+	Marker string
+	Attrs  Nodes
+}
+
+// String serialize node back to wikitext.
+func (row *TableRow) String() string {
+	return row.Marker + row.Attrs.String()
+}
+
+// TableCaption table caption ("|+ caption").
+type TableCaption struct {
+	Attrs    Nodes
+	HasAttrs bool
+	Content  Nodes
+}
+
+// String serialize node back to wikitext.
+func (cpt *TableCaption) String() string {
+	if cpt.HasAttrs {
+		return "|+" + cpt.Attrs.String() + "|" + cpt.Content.String()
+	}
+
+	return "|+" + cpt.Content.String()
+}
+
+// TableCell data ("| cell", "|| cell") or header ("! cell", "!! cell") cell,
+// attributes go before single pipe ("| style="color:red" | cell").
+type TableCell struct {
+	Marker   string
+	Attrs    Nodes
+	HasAttrs bool
+	Content  Nodes
+}
+
+// String serialize node back to wikitext.
+func (cell *TableCell) String() string {
+	if cell.HasAttrs {
+		return cell.Marker + cell.Attrs.String() + "|" + cell.Content.String()
+	}
+
+	return cell.Marker + cell.Content.String()
+}
+
+// Header check if cell is header cell.
+func (cell *TableCell) Header() bool {
+	return strings.HasPrefix(cell.Marker, "!")
+}
+
+// Text get cell content without surrounding whitespace.
+func (cell *TableCell) Text() string {
+	return strings.TrimSpace(cell.Content.String())
+}
+
+func (p *parser) parseTable(pos int) (Node, int, bool) {
+	if p.depth >= maxDepth || p.steps <= 0 {
+		return nil, pos, false
+	}
+
+	p.depth++
+	defer func() { p.depth-- }()
+
+	tbl := new(Table)
+	var end int
+	tbl.Attrs, end = p.parseNodes(pos+2, stopAt("\n"), false)
+
+	for end < len(p.src) && p.src[end] == '\n' {
+		start := end + 1
+
+		for start < len(p.src) && (p.src[start] == ' ' || p.src[start] == '\t') {
+			start++
+		}
+
+		tbl.Items = append(tbl.Items, &Text{Value: p.src[end:start]})
+		line := p.src[start:]
+
+		switch {
+		case strings.HasPrefix(line, "|}"):
+			tbl.Close = "|}"
+			return tbl, start + 2, true
+		case strings.HasPrefix(line, "|+"):
+			caption := new(TableCaption)
+			caption.Content, end = p.parseNodes(start+2, cellStop(false), true)
+			caption.Attrs, caption.Content, caption.HasAttrs = splitCellAttrs(caption.Content)
+			tbl.Items = append(tbl.Items, caption)
+		case strings.HasPrefix(line, "|-"):
+			markerEnd := start + 1
+
+			for markerEnd < len(p.src) && p.src[markerEnd] == '-' {
+				markerEnd++
+			}
+
+			row := &TableRow{Marker: p.src[start:markerEnd]}
+			row.Attrs, end = p.parseNodes(markerEnd, stopAt("\n"), false)
+			tbl.Items = append(tbl.Items, row)
+		case strings.HasPrefix(line, "|") || strings.HasPrefix(line, "!"):
+			header := line[0] == '!'
+			marker := line[:1]
+			end = start + 1
+
+			for {
+				cell := &TableCell{Marker: marker}
+				cell.Content, end = p.parseNodes(end, cellStop(header), true)
+				cell.Attrs, cell.Content, cell.HasAttrs = splitCellAttrs(cell.Content)
+				tbl.Items = append(tbl.Items, cell)
+
+				if !strings.HasPrefix(p.src[end:], "||") && !(header && strings.HasPrefix(p.src[end:], "!!")) {
+					break
+				}
+
+				marker = p.src[end : end+2]
+				end += 2
+			}
+		default:
+			var nodes Nodes
+			nodes, end = p.parseNodes(start, stopAt("\n"), false)
+			tbl.Items = append(tbl.Items, nodes...)
+		}
+	}
+
+	return tbl, end, true
+}
+
+// cellStop stop cell content on inline cell separator or on the line starting with table markup.
+func cellStop(header bool) stopFunc {
+	return func(src string, pos int) bool {
+		if strings.HasPrefix(src[pos:], "||") || (header && strings.HasPrefix(src[pos:], "!!")) {
+			return true
+		}
+
+		if src[pos] != '\n' {
+			return false
+		}
+
+		for pos++; pos < len(src) && (src[pos] == ' ' || src[pos] == '\t'); pos++ {
+		}
+
+		return pos < len(src) && (src[pos] == '|' || src[pos] == '!')
+	}
+}
+
+// splitCellAttrs split cell content into attributes and content on the first single pipe,
+// attributes can't span multiple lines or contain links.
+func splitCellAttrs(nodes Nodes) (Nodes, Nodes, bool) {
+	for idx, node := range nodes {
+		switch node := node.(type) {
+		case *Text:
+			pipe := strings.IndexByte(node.Value, '|')
+			newline := strings.IndexByte(node.Value, '\n')
+
+			if newline != -1 && (pipe == -1 || newline < pipe) {
+				return nil, nodes, false
+			}
+
+			if pipe == -1 {
+				continue
+			}
+
+			attrs := append(Nodes{}, nodes[:idx]...)
+			content := Nodes{}
+
+			if pipe > 0 {
+				attrs = append(attrs, &Text{Value: node.Value[:pipe]})
+			}
+
+			if pipe < len(node.Value)-1 {
+				content = append(content, &Text{Value: node.Value[pipe+1:]})
+			}
+
+			return attrs, append(content, nodes[idx+1:]...), true
+		case *Template, *ParserFunction, *Argument, *Comment:
+			continue
+		default:
+			return nil, nodes, false
+		}
+	}
+
+	return nil, nodes, false
+}
@@ -0,0 +1,59 @@
+package wikitext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tableTestWikitext = `{| class="wikitable"
+|+ style="font-weight:bold" | Clans
+! Clan !! Province
+|-
+| rowspan="2" | [[Iga]] || Iga
+|- style="color:red"
+| {{flag|Koga
+|country=Japan}}
+multi-line
+  | indented
+|}`
+
+func TestTable(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString(tableTestWikitext)
+	assert.Equal(tableTestWikitext, doc.String())
+
+	tbls := doc.Tables()
+	assert.Len(tbls, 1)
+
+	tbl := tbls[0]
+	assert.True(tbl.Closed())
+	assert.Equal(` class="wikitable"`, tbl.Attrs.String())
+	assert.True(tbl.Caption().HasAttrs)
+	assert.Equal(" Clans", tbl.Caption().Content.String())
+
+	rows := tbl.Rows()
+	assert.Len(rows, 3)
+	assert.Len(rows[0], 2)
+	assert.True(rows[0][0].Header())
+	assert.Equal("Province", rows[0][1].Text())
+
+	assert.True(rows[1][0].HasAttrs)
+	assert.Equal(` rowspan="2" `, rows[1][0].Attrs.String())
+	assert.Equal("[[Iga]]", rows[1][0].Text())
+	assert.False(rows[1][1].HasAttrs)
+
+	assert.Len(rows[2], 2)
+	assert.IsType(&Template{}, rows[2][0].Content[1])
+	assert.Equal("indented", rows[2][1].Text())
+}
+
+func TestTableUnclosed(t *testing.T) {
+	assert := assert.New(t)
+	doc := ParseString("{|\n| a\n{|\n| nested\n|}")
+
+	tbls := doc.Tables()
+	assert.Len(tbls, 2)
+	assert.False(tbls[0].Closed())
+	assert.True(tbls[1].Closed())
+}